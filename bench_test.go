@@ -17,6 +17,13 @@ type OptPayload struct {
 	Email param.Opt[string] `json:"email,omitempty"` // For omitempty test
 }
 
+// ValPayload uses the struct-backed param.Val[T] type.
+type ValPayload struct {
+	ID    param.Val[int]    `json:"id"`
+	Name  param.Val[string] `json:"name"`
+	Email param.Val[string] `json:"email,omitzero"` // For omitzero test
+}
+
 // PointerPayload uses the standard Go pointer types for nullability.
 type PointerPayload struct {
 	ID    *int    `json:"id"`
@@ -41,6 +48,13 @@ var (
 		Email: nil, // Unset, will be omitted.
 	}
 
+	// Data using the struct-backed Val type.
+	valData = ValPayload{
+		ID:   param.ValOf(idValue),
+		Name: param.NullVal[string](),
+		// Email is unset, will be omitted.
+	}
+
 	// Data using standard pointer types.
 	pointerData = PointerPayload{
 		ID:    &idValue,
@@ -65,6 +79,22 @@ func BenchmarkMarshal_WithOpt(b *testing.B) {
 	}
 }
 
+// BenchmarkMarshal_WithVal tests the performance of marshaling a struct that uses param.Val[T].
+func BenchmarkMarshal_WithVal(b *testing.B) {
+	b.ReportAllocs()
+	var r []byte
+	var err error
+
+	for i := 0; i < b.N; i++ {
+		r, err = json.Marshal(valData)
+	}
+
+	blackhole = r
+	if err != nil {
+		b.Fatal(err)
+	}
+}
+
 // BenchmarkMarshal_WithPointers tests the performance of marshaling a struct using standard pointers.
 func BenchmarkMarshal_WithPointers(b *testing.B) {
 	b.ReportAllocs()
@@ -97,6 +127,22 @@ func BenchmarkUnmarshal_WithOpt(b *testing.B) {
 	}
 }
 
+// BenchmarkUnmarshal_WithVal tests the performance of unmarshaling into a struct that uses param.Val[T].
+func BenchmarkUnmarshal_WithVal(b *testing.B) {
+	b.ReportAllocs()
+	var p ValPayload
+	var err error
+
+	for i := 0; i < b.N; i++ {
+		err = json.Unmarshal(jsonInput, &p)
+	}
+
+	blackhole = p
+	if err != nil {
+		b.Fatal(err)
+	}
+}
+
 // BenchmarkUnmarshal_WithPointers tests the performance of unmarshaling into a struct using standard pointers.
 func BenchmarkUnmarshal_WithPointers(b *testing.B) {
 	b.ReportAllocs()
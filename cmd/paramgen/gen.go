@@ -0,0 +1,483 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/format"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	paramPath = "github.com/qntx/param"
+	directive = "//paramgen:patch"
+)
+
+// options are the directive options of a struct.
+type options struct {
+	name    string
+	exclude []string
+	recurse bool
+}
+
+// patchType is a patch struct to generate.
+type patchType struct {
+	Name   string
+	Target string
+	Fields []patchField
+}
+
+// patchField is a field of a patch struct mirroring a field of its target.
+type patchField struct {
+	// Name is the field name, the same in the patch and the target.
+	Name string
+	// Type is the type of the patch field.
+	Type string
+	// Tag is the struct tag of the patch field, without backquotes.
+	Tag string
+	// Key is the json name of the field.
+	Key string
+	// Kind tells how the field is applied to the target.
+	Kind fieldKind
+	// Nested is the target type of a nested patch field.
+	Nested string
+}
+
+// fieldKind tells how a patch field is applied to its target field.
+type fieldKind int
+
+const (
+	// kindValue fields target a T, set to the zero value on null.
+	kindValue fieldKind = iota
+	// kindPointer fields target a *T, set to nil on null.
+	kindPointer
+	// kindOpt fields target the same Opt or Val type and are copied.
+	kindOpt
+	// kindNested fields hold a nested patch applied to a struct.
+	kindNested
+	// kindNestedPointer fields hold a nested patch applied to a struct pointer,
+	// allocated if nil.
+	kindNestedPointer
+)
+
+// generator accumulates the patch structs of a package.
+type generator struct {
+	pkg     *types.Package
+	opts    map[string]options
+	types   []*patchType
+	done    map[string]*patchType
+	imports map[string]string
+	// err is the first type checking error.
+	err error
+}
+
+// generate parses the package in dir, ignoring the file output, and returns the
+// source of the patch structs for its annotated structs.
+func generate(dir, output string) ([]byte, error) {
+	fset := token.NewFileSet()
+	files, err := parse(fset, dir, output)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := map[string]options{}
+	var names []string
+	for _, f := range files {
+		for _, decl := range f.Decls {
+			gd, ok := decl.(*ast.GenDecl)
+			if !ok || gd.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range gd.Specs {
+				ts := spec.(*ast.TypeSpec)
+				doc := ts.Doc
+				if doc == nil && len(gd.Specs) == 1 {
+					doc = gd.Doc
+				}
+				o, ok, err := parseDirective(doc)
+				if err != nil {
+					return nil, fmt.Errorf("%s: %w", fset.Position(ts.Pos()), err)
+				}
+				if ok {
+					opts[ts.Name.Name] = o
+					names = append(names, ts.Name.Name)
+				}
+			}
+		}
+	}
+	if len(names) == 0 {
+		return nil, fmt.Errorf("no struct in %s has a %s directive", dir, directive)
+	}
+
+	// Errors such as unused imports must not prevent generation, so the first
+	// one is only reported if a mirrored field cannot be resolved.
+	g := &generator{opts: opts, done: map[string]*patchType{}, imports: map[string]string{}}
+	conf := types.Config{
+		Importer: importer.ForCompiler(fset, "source", nil),
+		Error: func(err error) {
+			if g.err == nil {
+				g.err = err
+			}
+		},
+	}
+	g.pkg, _ = conf.Check(files[0].Name.Name, fset, files, nil)
+	for _, name := range names {
+		if _, err := g.patch(name); err != nil {
+			return nil, err
+		}
+	}
+	return g.source()
+}
+
+// parse parses the Go files of the package in dir, except output.
+func parse(fset *token.FileSet, dir, output string) ([]*ast.File, error) {
+	bp, err := build.ImportDir(dir, 0)
+	if err != nil {
+		return nil, err
+	}
+	var files []*ast.File
+	for _, name := range bp.GoFiles {
+		path := filepath.Join(dir, name)
+		if same(path, output) {
+			continue
+		}
+		f, err := parser.ParseFile(fset, path, nil, parser.ParseComments)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, f)
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no Go files in %s", dir)
+	}
+	return files, nil
+}
+
+func same(a, b string) bool {
+	a, errA := filepath.Abs(a)
+	b, errB := filepath.Abs(b)
+	return errA == nil && errB == nil && a == b
+}
+
+// parseDirective parses the options of the directive in doc, reporting whether
+// there is one.
+func parseDirective(doc *ast.CommentGroup) (options, bool, error) {
+	if doc == nil {
+		return options{}, false, nil
+	}
+	for _, c := range doc.List {
+		args, ok := strings.CutPrefix(c.Text, directive)
+		if !ok || args != "" && args[0] != ' ' && args[0] != '\t' {
+			continue
+		}
+		var o options
+		for _, arg := range strings.Fields(args) {
+			key, val, _ := strings.Cut(arg, "=")
+			switch key {
+			case "name":
+				o.name = val
+			case "exclude":
+				o.exclude = strings.Split(val, ",")
+			case "recurse":
+				o.recurse = true
+			default:
+				return options{}, false, fmt.Errorf("unknown %s option %q", directive, arg)
+			}
+		}
+		return o, true, nil
+	}
+	return options{}, false, nil
+}
+
+// patch returns the patch struct of the struct named name, generating it on first
+// use.
+func (g *generator) patch(name string) (*patchType, error) {
+	if pt, ok := g.done[name]; ok {
+		return pt, nil
+	}
+	obj, _ := g.pkg.Scope().Lookup(name).(*types.TypeName)
+	if obj == nil {
+		return nil, fmt.Errorf("type %s cannot be resolved", name)
+	}
+	st, ok := obj.Type().Underlying().(*types.Struct)
+	if !ok {
+		return nil, fmt.Errorf("%s is not a struct", name)
+	}
+
+	o := g.opts[name]
+	if o.name == "" {
+		o.name = name + "Patch"
+	}
+	pt := &patchType{Name: o.name, Target: name}
+	g.done[name] = pt
+	g.types = append(g.types, pt)
+
+	fields, err := g.fields(st, o, map[string]bool{})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	pt.Fields = fields
+	return pt, nil
+}
+
+// fields returns the patch fields mirroring the fields of st. Embedded structs
+// without a json tag are flattened like encoding/json does, with shallower fields
+// taking precedence; seen holds the names taken by shallower fields.
+func (g *generator) fields(st *types.Struct, o options, seen map[string]bool) ([]patchField, error) {
+	var (
+		out      []patchField
+		embedded []*types.Struct
+	)
+	for i := 0; i < st.NumFields(); i++ {
+		v := st.Field(i)
+		tag := reflect.StructTag(st.Tag(i))
+		if tag.Get("patch") == "-" || slices.Contains(o.exclude, v.Name()) {
+			continue
+		}
+		if v.Embedded() {
+			if es, ok := v.Type().Underlying().(*types.Struct); ok && !hasTag(tag, "json") {
+				embedded = append(embedded, es)
+				continue
+			}
+		}
+		if !v.Exported() || tag.Get("json") == "-" || seen[v.Name()] {
+			continue
+		}
+		seen[v.Name()] = true
+
+		f := patchField{Name: v.Name(), Tag: patchTag(tag), Key: v.Name()}
+		if name, _, _ := strings.Cut(tag.Get("json"), ","); name != "" {
+			f.Key = name
+		}
+		if err := g.fieldType(&f, v.Type(), o); err != nil {
+			return nil, fmt.Errorf("field %s: %w", v.Name(), err)
+		}
+		out = append(out, f)
+	}
+	for _, es := range embedded {
+		fields, err := g.fields(es, o, seen)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, fields...)
+	}
+	return out, nil
+}
+
+// fieldType sets the type and kind of the patch field f mirroring a field of type
+// typ.
+func (g *generator) fieldType(f *patchField, typ types.Type, o options) error {
+	if !valid(typ) {
+		return g.err
+	}
+	if isOpt(typ) {
+		f.Type, f.Kind = types.TypeString(typ, g.qualifier), kindOpt
+		return nil
+	}
+	f.Kind = kindValue
+	if ptr, ok := typ.(*types.Pointer); ok {
+		typ, f.Kind = ptr.Elem(), kindPointer
+	}
+	elem := types.TypeString(typ, g.qualifier)
+	if named, ok := typ.(*types.Named); ok && o.recurse && g.nested(named) {
+		if _, ok := g.opts[named.Obj().Name()]; !ok {
+			g.opts[named.Obj().Name()] = options{recurse: true}
+		}
+		pt, err := g.patch(named.Obj().Name())
+		if err != nil {
+			return err
+		}
+		f.Nested, elem = elem, pt.Name
+		if f.Kind == kindPointer {
+			f.Kind = kindNestedPointer
+		} else {
+			f.Kind = kindNested
+		}
+	}
+	g.imports[paramPath] = "param"
+	f.Type = "param.Opt[" + elem + "]"
+	return nil
+}
+
+// nested reports whether named is a plain struct of the package, which can be
+// mirrored by a patch struct.
+func (g *generator) nested(named *types.Named) bool {
+	if named.Obj().Pkg() != g.pkg || named.TypeParams().Len() > 0 {
+		return false
+	}
+	if _, ok := named.Underlying().(*types.Struct); !ok {
+		return false
+	}
+	mset := types.NewMethodSet(types.NewPointer(named))
+	for _, m := range []string{"MarshalJSON", "MarshalText"} {
+		if mset.Lookup(named.Obj().Pkg(), m) != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// qualifier qualifies types of other packages by package name and records the
+// imports they need.
+func (g *generator) qualifier(p *types.Package) string {
+	if p == g.pkg {
+		return ""
+	}
+	g.imports[p.Path()] = p.Name()
+	return p.Name()
+}
+
+// isOpt reports whether typ is already a param.Opt or param.Val.
+func isOpt(typ types.Type) bool {
+	named, ok := typ.(*types.Named)
+	if !ok || named.Obj().Pkg() == nil || named.Obj().Pkg().Path() != paramPath {
+		return false
+	}
+	return named.Obj().Name() == "Opt" || named.Obj().Name() == "Val"
+}
+
+// valid reports whether typ and the types it is built from were resolved.
+func valid(typ types.Type) bool {
+	return !strings.Contains(types.TypeString(typ, nil), "invalid type")
+}
+
+func hasTag(tag reflect.StructTag, key string) bool {
+	_, ok := tag.Lookup(key)
+	return ok
+}
+
+// patchTag returns the tag of a patch field mirroring a field tagged tag: the json
+// tag gains omitempty (and loses the string option, which Opt does not support)
+// and `param` and `patch` tags are dropped.
+func patchTag(tag reflect.StructTag) string {
+	parts := []string{}
+	json := `json:",omitempty"`
+	for _, kv := range tags(tag) {
+		switch kv[0] {
+		case "param", "patch":
+		case "json":
+			name, opts, _ := strings.Cut(kv[1], ",")
+			kept := []string{name}
+			for _, o := range strings.Split(opts, ",") {
+				if o != "" && o != "string" && o != "omitempty" {
+					kept = append(kept, o)
+				}
+			}
+			json = "json:" + strconv.Quote(strings.Join(append(kept, "omitempty"), ","))
+		default:
+			parts = append(parts, kv[0]+":"+strconv.Quote(kv[1]))
+		}
+	}
+	return strings.Join(append([]string{json}, parts...), " ")
+}
+
+// tags splits a conventional struct tag into its key and value pairs.
+func tags(tag reflect.StructTag) [][2]string {
+	var out [][2]string
+	s := string(tag)
+	for {
+		s = strings.TrimLeft(s, " ")
+		key, rest, ok := strings.Cut(s, ":")
+		if !ok || len(rest) == 0 || rest[0] != '"' {
+			return out
+		}
+		val, err := strconv.QuotedPrefix(rest)
+		if err != nil {
+			return out
+		}
+		s = rest[len(val):]
+		unquoted, _ := strconv.Unquote(val)
+		out = append(out, [2]string{key, unquoted})
+	}
+}
+
+// source returns the formatted source of the generated file.
+func (g *generator) source() ([]byte, error) {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by paramgen; DO NOT EDIT.\n\npackage %s\n\n", g.pkg.Name())
+
+	paths := make([]string, 0, len(g.imports))
+	for path := range g.imports {
+		paths = append(paths, path)
+	}
+	// Standard library imports come first, as goimports groups them.
+	sort.Slice(paths, func(i, j int) bool {
+		if std(paths[i]) != std(paths[j]) {
+			return std(paths[i])
+		}
+		return paths[i] < paths[j]
+	})
+	buf.WriteString("import (\n")
+	for i, path := range paths {
+		if i > 0 && std(paths[i-1]) && !std(path) {
+			buf.WriteString("\n")
+		}
+		fmt.Fprintf(&buf, "\t%q\n", path)
+	}
+	buf.WriteString(")\n")
+
+	for _, pt := range g.types {
+		fmt.Fprintf(&buf, "\n// %s is a partial update of %s.\ntype %s struct {\n", pt.Name, pt.Target, pt.Name)
+		for _, f := range pt.Fields {
+			fmt.Fprintf(&buf, "\t%s %s `%s`\n", f.Name, f.Type, f.Tag)
+		}
+		buf.WriteString("}\n")
+		writeApplyTo(&buf, pt)
+		writeChangedFields(&buf, pt)
+	}
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("generated invalid code: %w", err)
+	}
+	return src, nil
+}
+
+// writeApplyTo writes the ApplyTo method of pt.
+func writeApplyTo(buf *bytes.Buffer, pt *patchType) {
+	fmt.Fprintf(buf, "\n// ApplyTo applies the set fields of p to t. Null fields are reset to their zero\n")
+	fmt.Fprintf(buf, "// value or nil and unset fields are left untouched.\n")
+	fmt.Fprintf(buf, "func (p %s) ApplyTo(t *%s) {\n", pt.Name, pt.Target)
+	for _, f := range pt.Fields {
+		switch f.Kind {
+		case kindValue:
+			fmt.Fprintf(buf, "if p.%[1]s.IsSet() {\nt.%[1]s, _ = p.%[1]s.Get()\n}\n", f.Name)
+		case kindPointer:
+			fmt.Fprintf(buf, "if v, ok := p.%[1]s.Get(); ok {\nt.%[1]s = &v\n} else if p.%[1]s.IsNull() {\nt.%[1]s = nil\n}\n", f.Name)
+		case kindOpt:
+			fmt.Fprintf(buf, "if p.%[1]s.IsSet() {\nt.%[1]s = p.%[1]s\n}\n", f.Name)
+		case kindNested:
+			fmt.Fprintf(buf, "if v, ok := p.%[1]s.Get(); ok {\nv.ApplyTo(&t.%[1]s)\n} else if p.%[1]s.IsNull() {\nt.%[1]s = %[2]s{}\n}\n", f.Name, f.Nested)
+		case kindNestedPointer:
+			fmt.Fprintf(buf, "if v, ok := p.%[1]s.Get(); ok {\nif t.%[1]s == nil {\nt.%[1]s = new(%[2]s)\n}\nv.ApplyTo(t.%[1]s)\n} else if p.%[1]s.IsNull() {\nt.%[1]s = nil\n}\n", f.Name, f.Nested)
+		}
+	}
+	buf.WriteString("}\n")
+}
+
+// writeChangedFields writes the ChangedFields method of pt.
+func writeChangedFields(buf *bytes.Buffer, pt *patchType) {
+	fmt.Fprintf(buf, "\n// ChangedFields returns the json names of the fields of p that are set, either\n")
+	fmt.Fprintf(buf, "// to null or to a value.\n")
+	fmt.Fprintf(buf, "func (p %s) ChangedFields() []string {\nvar fields []string\n", pt.Name)
+	for _, f := range pt.Fields {
+		fmt.Fprintf(buf, "if p.%s.IsSet() {\nfields = append(fields, %q)\n}\n", f.Name, f.Key)
+	}
+	buf.WriteString("return fields\n}\n")
+}
+
+// std reports whether the import path belongs to the standard library.
+func std(path string) bool {
+	elem, _, _ := strings.Cut(path, "/")
+	return !strings.Contains(elem, ".")
+}
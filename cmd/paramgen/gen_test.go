@@ -0,0 +1,86 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "update the golden files")
+
+// TestGenerate validates the generated code against the golden file.
+func TestGenerate(t *testing.T) {
+	dir := filepath.Join("testdata", "models")
+	golden := filepath.Join(dir, "patch_gen.go")
+
+	got, err := generate(dir, golden)
+	if err != nil {
+		t.Fatalf("generate() returned an unexpected error: %v", err)
+	}
+	if *update {
+		if err := os.WriteFile(golden, got, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("generate() got:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+// TestGeneratedCompiles validates that the golden file type checks with its
+// package.
+func TestGeneratedCompiles(t *testing.T) {
+	fset := token.NewFileSet()
+	pkgs, err := filepath.Glob(filepath.Join("testdata", "models", "*.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var files []*ast.File
+	for _, path := range pkgs {
+		f, err := parser.ParseFile(fset, path, nil, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		files = append(files, f)
+	}
+	conf := types.Config{Importer: importer.ForCompiler(fset, "source", nil)}
+	if _, err := conf.Check("models", fset, files, nil); err != nil {
+		t.Errorf("generated code does not type check: %v", err)
+	}
+}
+
+// TestGenerateErrors validates the reported errors.
+func TestGenerateErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+	}{
+		{"NoDirective", "package p\n\ntype User struct{ Name string }\n"},
+		{"UnknownOption", "package p\n\n//paramgen:patch omit=Name\ntype User struct{ Name string }\n"},
+		{"NotStruct", "package p\n\n//paramgen:patch\ntype ID int\n"},
+		{"Unresolved", "package p\n\n//paramgen:patch\ntype User struct{ Name Missing }\n"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			if err := os.WriteFile(filepath.Join(dir, "p.go"), []byte(tc.src), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := generate(dir, filepath.Join(dir, "patch_gen.go")); err == nil {
+				t.Error("Expected an error but got nil")
+			}
+		})
+	}
+}
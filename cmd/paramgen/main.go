@@ -0,0 +1,64 @@
+// Paramgen generates patch structs of param.Opt fields from domain structs.
+//
+// Add a directive to the doc comment of each struct to mirror, and a go:generate
+// line to the package:
+//
+//	//go:generate go run github.com/qntx/param/cmd/paramgen
+//
+//	//paramgen:patch
+//	type User struct {
+//		ID      int64     `json:"id" patch:"-"`
+//		Name    string    `json:"name"`
+//		Email   *string   `json:"email"`
+//		Address Address   `json:"address"`
+//	}
+//
+// Running go generate writes a UserPatch struct to patch_gen.go with one
+// param.Opt[T] field per field of User. Pointer fields become Opt of the pointed-to
+// type, existing Opt and Val fields are kept as they are, and json tags are kept
+// with omitempty added. Other struct tags are copied except `param` validation
+// rules, since rules such as required do not hold for partial updates.
+//
+// Each patch struct also gets two reflection-free methods: ApplyTo(*User) copies
+// the set fields onto a User, resetting null fields to their zero value or nil
+// and leaving unset fields untouched, and ChangedFields returns the json names of
+// the set fields.
+//
+// The directive accepts space-separated options:
+//
+//	name=UserUpdate  name the patch struct (default: the type name + "Patch")
+//	exclude=ID,Slug  leave out the listed fields, like a `patch:"-"` tag
+//	recurse          mirror nested structs of the package as patch structs too,
+//	                 so Address becomes param.Opt[AddressPatch]
+//
+// Usage:
+//
+//	paramgen [-dir directory] [-output file]
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+func main() {
+	dir := flag.String("dir", ".", "package `directory` to parse")
+	output := flag.String("output", "patch_gen.go", "output `file`, relative to the package directory")
+	flag.Parse()
+
+	out := *output
+	if !filepath.IsAbs(out) {
+		out = filepath.Join(*dir, out)
+	}
+	src, err := generate(*dir, out)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "paramgen:", err)
+		os.Exit(1)
+	}
+	if err := os.WriteFile(out, src, 0o644); err != nil {
+		fmt.Fprintln(os.Stderr, "paramgen:", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,46 @@
+package models
+
+import (
+	"time"
+
+	"github.com/qntx/param"
+)
+
+//go:generate go run github.com/qntx/param/cmd/paramgen
+
+// Base holds the fields shared by every model.
+type Base struct {
+	ID        int64     `json:"id" db:"id"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+// User is a user account.
+//
+//paramgen:patch exclude=ID,CreatedAt recurse
+type User struct {
+	Base
+	Name     string            `json:"name" db:"name" param:"required"`
+	Email    *string           `json:"email,omitempty" db:"email"`
+	Age      int               `json:"age,string"`
+	Nickname param.Opt[string] `json:"nickname"`
+	Address  Address           `json:"address"`
+	Manager  *User             `json:"manager"`
+	Tags     []string
+	Password string `json:"-"`
+	Version  int    `json:"version" patch:"-"`
+	internal bool
+}
+
+// Address is a postal address.
+type Address struct {
+	Street string  `json:"street"`
+	City   string  `json:"city"`
+	Geo    *[2]int `json:"geo"`
+}
+
+//paramgen:patch name=SettingsUpdate
+type Settings struct {
+	Theme   string        `json:"theme"`
+	Timeout time.Duration `json:"timeout"`
+	Address Address       `json:"address"`
+}
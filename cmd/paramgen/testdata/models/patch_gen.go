@@ -0,0 +1,159 @@
+// Code generated by paramgen; DO NOT EDIT.
+
+package models
+
+import (
+	"time"
+
+	"github.com/qntx/param"
+)
+
+// UserPatch is a partial update of User.
+type UserPatch struct {
+	Name     param.Opt[string]       `json:"name,omitempty" db:"name"`
+	Email    param.Opt[string]       `json:"email,omitempty" db:"email"`
+	Age      param.Opt[int]          `json:"age,omitempty"`
+	Nickname param.Opt[string]       `json:"nickname,omitempty"`
+	Address  param.Opt[AddressPatch] `json:"address,omitempty"`
+	Manager  param.Opt[UserPatch]    `json:"manager,omitempty"`
+	Tags     param.Opt[[]string]     `json:",omitempty"`
+}
+
+// ApplyTo applies the set fields of p to t. Null fields are reset to their zero
+// value or nil and unset fields are left untouched.
+func (p UserPatch) ApplyTo(t *User) {
+	if p.Name.IsSet() {
+		t.Name, _ = p.Name.Get()
+	}
+	if v, ok := p.Email.Get(); ok {
+		t.Email = &v
+	} else if p.Email.IsNull() {
+		t.Email = nil
+	}
+	if p.Age.IsSet() {
+		t.Age, _ = p.Age.Get()
+	}
+	if p.Nickname.IsSet() {
+		t.Nickname = p.Nickname
+	}
+	if v, ok := p.Address.Get(); ok {
+		v.ApplyTo(&t.Address)
+	} else if p.Address.IsNull() {
+		t.Address = Address{}
+	}
+	if v, ok := p.Manager.Get(); ok {
+		if t.Manager == nil {
+			t.Manager = new(User)
+		}
+		v.ApplyTo(t.Manager)
+	} else if p.Manager.IsNull() {
+		t.Manager = nil
+	}
+	if p.Tags.IsSet() {
+		t.Tags, _ = p.Tags.Get()
+	}
+}
+
+// ChangedFields returns the json names of the fields of p that are set, either
+// to null or to a value.
+func (p UserPatch) ChangedFields() []string {
+	var fields []string
+	if p.Name.IsSet() {
+		fields = append(fields, "name")
+	}
+	if p.Email.IsSet() {
+		fields = append(fields, "email")
+	}
+	if p.Age.IsSet() {
+		fields = append(fields, "age")
+	}
+	if p.Nickname.IsSet() {
+		fields = append(fields, "nickname")
+	}
+	if p.Address.IsSet() {
+		fields = append(fields, "address")
+	}
+	if p.Manager.IsSet() {
+		fields = append(fields, "manager")
+	}
+	if p.Tags.IsSet() {
+		fields = append(fields, "Tags")
+	}
+	return fields
+}
+
+// AddressPatch is a partial update of Address.
+type AddressPatch struct {
+	Street param.Opt[string] `json:"street,omitempty"`
+	City   param.Opt[string] `json:"city,omitempty"`
+	Geo    param.Opt[[2]int] `json:"geo,omitempty"`
+}
+
+// ApplyTo applies the set fields of p to t. Null fields are reset to their zero
+// value or nil and unset fields are left untouched.
+func (p AddressPatch) ApplyTo(t *Address) {
+	if p.Street.IsSet() {
+		t.Street, _ = p.Street.Get()
+	}
+	if p.City.IsSet() {
+		t.City, _ = p.City.Get()
+	}
+	if v, ok := p.Geo.Get(); ok {
+		t.Geo = &v
+	} else if p.Geo.IsNull() {
+		t.Geo = nil
+	}
+}
+
+// ChangedFields returns the json names of the fields of p that are set, either
+// to null or to a value.
+func (p AddressPatch) ChangedFields() []string {
+	var fields []string
+	if p.Street.IsSet() {
+		fields = append(fields, "street")
+	}
+	if p.City.IsSet() {
+		fields = append(fields, "city")
+	}
+	if p.Geo.IsSet() {
+		fields = append(fields, "geo")
+	}
+	return fields
+}
+
+// SettingsUpdate is a partial update of Settings.
+type SettingsUpdate struct {
+	Theme   param.Opt[string]        `json:"theme,omitempty"`
+	Timeout param.Opt[time.Duration] `json:"timeout,omitempty"`
+	Address param.Opt[Address]       `json:"address,omitempty"`
+}
+
+// ApplyTo applies the set fields of p to t. Null fields are reset to their zero
+// value or nil and unset fields are left untouched.
+func (p SettingsUpdate) ApplyTo(t *Settings) {
+	if p.Theme.IsSet() {
+		t.Theme, _ = p.Theme.Get()
+	}
+	if p.Timeout.IsSet() {
+		t.Timeout, _ = p.Timeout.Get()
+	}
+	if p.Address.IsSet() {
+		t.Address, _ = p.Address.Get()
+	}
+}
+
+// ChangedFields returns the json names of the fields of p that are set, either
+// to null or to a value.
+func (p SettingsUpdate) ChangedFields() []string {
+	var fields []string
+	if p.Theme.IsSet() {
+		fields = append(fields, "theme")
+	}
+	if p.Timeout.IsSet() {
+		fields = append(fields, "timeout")
+	}
+	if p.Address.IsSet() {
+		fields = append(fields, "address")
+	}
+	return fields
+}
@@ -0,0 +1,74 @@
+package param
+
+// Map applies f to the value of o, if present. A null Opt maps to null and an unset
+// Opt stays unset.
+func Map[T, U any](o Opt[T], f func(T) U) Opt[U] {
+	if o.IsNull() {
+		return Null[U]()
+	}
+	if v, ok := o.Get(); ok {
+		return From(f(v))
+	}
+	return Zero[U]()
+}
+
+// FlatMap applies f to the value of o, if present, and returns its result. A null
+// Opt maps to null and an unset Opt stays unset.
+func FlatMap[T, U any](o Opt[T], f func(T) Opt[U]) Opt[U] {
+	if o.IsNull() {
+		return Null[U]()
+	}
+	if v, ok := o.Get(); ok {
+		return f(v)
+	}
+	return Zero[U]()
+}
+
+// Filter returns o if its value satisfies pred, and an unset Opt otherwise. Null and
+// unset Opts are returned as is.
+func Filter[T any](o Opt[T], pred func(T) bool) Opt[T] {
+	if v, ok := o.Get(); ok && !pred(v) {
+		return Zero[T]()
+	}
+	return o
+}
+
+// Or returns o if it is set (either as null or a value), and alt otherwise.
+func Or[T any](o, alt Opt[T]) Opt[T] {
+	if o.IsSet() {
+		return o
+	}
+	return alt
+}
+
+// OrElse returns the value of o, if present, and the result of f otherwise.
+func OrElse[T any](o Opt[T], f func() T) T {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return f()
+}
+
+// GetOr returns the value of o, if present, and def otherwise.
+func GetOr[T any](o Opt[T], def T) T {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return def
+}
+
+// Coalesce returns the first Opt holding a value. If none does, it returns null if
+// any of them is null, and an unset Opt otherwise.
+func Coalesce[T any](opts ...Opt[T]) Opt[T] {
+	null := false
+	for _, o := range opts {
+		if _, ok := o.Get(); ok {
+			return o
+		}
+		null = null || o.IsNull()
+	}
+	if null {
+		return Null[T]()
+	}
+	return Zero[T]()
+}
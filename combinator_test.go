@@ -0,0 +1,97 @@
+package param_test
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/qntx/param"
+)
+
+// assertState is a helper checking the state and value of an Opt.
+func assertState[T comparable](t *testing.T, got param.Opt[T], isSet, isNull bool, value T) {
+	t.Helper()
+	if got.IsSet() != isSet || got.IsNull() != isNull {
+		t.Fatalf("got state (set=%v, null=%v), want (set=%v, null=%v)", got.IsSet(), got.IsNull(), isSet, isNull)
+	}
+	if v, _ := got.Get(); v != value {
+		t.Errorf("Get() got %v, want %v", v, value)
+	}
+}
+
+// TestMap validates that Map preserves null and unset states.
+func TestMap(t *testing.T) {
+	itoa := func(i int) string { return strconv.Itoa(i) }
+
+	assertState(t, param.Map(param.From(42), itoa), true, false, "42")
+	assertState(t, param.Map(param.Null[int](), itoa), true, true, "")
+	assertState(t, param.Map(param.Zero[int](), itoa), false, false, "")
+	assertState(t, param.Map(param.Opt[int](nil), itoa), false, false, "")
+}
+
+// TestFlatMap validates that FlatMap preserves null and unset states.
+func TestFlatMap(t *testing.T) {
+	parse := func(s string) param.Opt[int] {
+		i, err := strconv.Atoi(s)
+		if err != nil {
+			return param.Null[int]()
+		}
+		return param.From(i)
+	}
+
+	assertState(t, param.FlatMap(param.From("42"), parse), true, false, 42)
+	assertState(t, param.FlatMap(param.From("x"), parse), true, true, 0)
+	assertState(t, param.FlatMap(param.Null[string](), parse), true, true, 0)
+	assertState(t, param.FlatMap(param.Zero[string](), parse), false, false, 0)
+}
+
+// TestFilter validates that Filter only discards values failing the predicate.
+func TestFilter(t *testing.T) {
+	positive := func(i int) bool { return i > 0 }
+
+	assertState(t, param.Filter(param.From(1), positive), true, false, 1)
+	assertState(t, param.Filter(param.From(-1), positive), false, false, 0)
+	assertState(t, param.Filter(param.Null[int](), positive), true, true, 0)
+	assertState(t, param.Filter(param.Zero[int](), positive), false, false, 0)
+}
+
+// TestOr validates that Or only falls back for unset Opts.
+func TestOr(t *testing.T) {
+	alt := param.From(2)
+
+	assertState(t, param.Or(param.From(1), alt), true, false, 1)
+	assertState(t, param.Or(param.Null[int](), alt), true, true, 0)
+	assertState(t, param.Or(param.Zero[int](), alt), true, false, 2)
+}
+
+// TestGetOr validates the GetOr and OrElse fallbacks.
+func TestGetOr(t *testing.T) {
+	testCases := []struct {
+		name string
+		n    param.Opt[int]
+		want int
+	}{
+		{name: "Valid", n: param.From(1), want: 1},
+		{name: "Valid zero value", n: param.From(0), want: 0},
+		{name: "Null", n: param.Null[int](), want: 9},
+		{name: "Unset", n: nil, want: 9},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := param.GetOr(tc.n, 9); got != tc.want {
+				t.Errorf("GetOr() got %d, want %d", got, tc.want)
+			}
+			if got := param.OrElse(tc.n, func() int { return 9 }); got != tc.want {
+				t.Errorf("OrElse() got %d, want %d", got, tc.want)
+			}
+		})
+	}
+}
+
+// TestCoalesce validates that Coalesce picks the first value and keeps nulls.
+func TestCoalesce(t *testing.T) {
+	assertState(t, param.Coalesce(param.Zero[int](), param.Null[int](), param.From(3), param.From(4)), true, false, 3)
+	assertState(t, param.Coalesce(param.Zero[int](), param.Null[int]()), true, true, 0)
+	assertState(t, param.Coalesce(param.Zero[int](), nil), false, false, 0)
+	assertState(t, param.Coalesce[int](), false, false, 0)
+}
@@ -0,0 +1,56 @@
+package param
+
+import "cmp"
+
+// rank orders the states of an Opt: unset < null < value.
+func rank[T any](o Opt[T]) int {
+	switch {
+	case !o.IsSet():
+		return 0
+	case o.IsNull():
+		return 1
+	}
+	return 2
+}
+
+// Equal reports whether a and b are in the same state and, if both hold a value,
+// whether the values are equal.
+func Equal[T comparable](a, b Opt[T]) bool {
+	return EqualFunc(a, b, func(x, y T) bool { return x == y })
+}
+
+// EqualFunc is like Equal but compares values with eq.
+func EqualFunc[T any](a, b Opt[T], eq func(T, T) bool) bool {
+	if rank(a) != rank(b) {
+		return false
+	}
+	x, ok := a.Get()
+	if !ok {
+		return true
+	}
+	y, _ := b.Get()
+	return eq(x, y)
+}
+
+// Compare returns -1, 0 or +1 depending on whether a is less than, equal to or
+// greater than b, under the total order unset < null < value, with values ordered
+// by cmp.Compare. It can be passed to slices.SortFunc.
+func Compare[T cmp.Ordered](a, b Opt[T]) int {
+	return CompareFunc(a, b, cmp.Compare[T])
+}
+
+// CompareFunc is like Compare but orders values with cmp.
+func CompareFunc[T any](a, b Opt[T], cmp func(T, T) int) int {
+	if ra, rb := rank(a), rank(b); ra != rb {
+		if ra < rb {
+			return -1
+		}
+		return 1
+	}
+	x, ok := a.Get()
+	if !ok {
+		return 0
+	}
+	y, _ := b.Get()
+	return cmp(x, y)
+}
@@ -0,0 +1,102 @@
+package param_test
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/qntx/param"
+)
+
+// TestEqual validates equality across all states.
+func TestEqual(t *testing.T) {
+	testCases := []struct {
+		name string
+		a, b param.Opt[int]
+		want bool
+	}{
+		{name: "Unset and unset", a: nil, b: param.Zero[int](), want: true},
+		{name: "Null and null", a: param.Null[int](), b: param.Null[int](), want: true},
+		{name: "Same values", a: param.From(1), b: param.From(1), want: true},
+		{name: "Different values", a: param.From(1), b: param.From(2), want: false},
+		{name: "Unset and null", a: nil, b: param.Null[int](), want: false},
+		{name: "Null and zero value", a: param.Null[int](), b: param.From(0), want: false},
+		{name: "Unset and zero value", a: nil, b: param.From(0), want: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := param.Equal(tc.a, tc.b); got != tc.want {
+				t.Errorf("Equal() got %v, want %v", got, tc.want)
+			}
+			if got := param.Equal(tc.b, tc.a); got != tc.want {
+				t.Errorf("Equal() (swapped) got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+// TestEqualFunc validates equality with a custom comparison.
+func TestEqualFunc(t *testing.T) {
+	if !param.EqualFunc(param.From("Go"), param.From("GO"), strings.EqualFold) {
+		t.Error("EqualFunc() got false for values equal under eq")
+	}
+	if param.EqualFunc(param.From("Go"), param.Null[string](), strings.EqualFold) {
+		t.Error("EqualFunc() got true for a value and null")
+	}
+
+	a := []param.Opt[int]{param.From(1), param.Null[int](), nil}
+	b := []param.Opt[int]{param.From(1), param.Null[int](), param.Zero[int]()}
+	if !slices.EqualFunc(a, b, param.Equal[int]) {
+		t.Error("slices.EqualFunc() with Equal got false, want true")
+	}
+}
+
+// TestCompare validates the total order unset < null < value.
+func TestCompare(t *testing.T) {
+	testCases := []struct {
+		name string
+		a, b param.Opt[int]
+		want int
+	}{
+		{name: "Unset vs unset", a: nil, b: param.Zero[int](), want: 0},
+		{name: "Unset vs null", a: nil, b: param.Null[int](), want: -1},
+		{name: "Null vs value", a: param.Null[int](), b: param.From(-5), want: -1},
+		{name: "Value vs unset", a: param.From(-5), b: nil, want: 1},
+		{name: "Null vs null", a: param.Null[int](), b: param.Null[int](), want: 0},
+		{name: "Smaller value", a: param.From(1), b: param.From(2), want: -1},
+		{name: "Equal values", a: param.From(2), b: param.From(2), want: 0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := param.Compare(tc.a, tc.b); got != tc.want {
+				t.Errorf("Compare() got %d, want %d", got, tc.want)
+			}
+			if got := param.Compare(tc.b, tc.a); got != -tc.want {
+				t.Errorf("Compare() (swapped) got %d, want %d", got, -tc.want)
+			}
+		})
+	}
+}
+
+// TestCompareSort validates sorting with Compare and CompareFunc.
+func TestCompareSort(t *testing.T) {
+	s := []param.Opt[int]{param.From(3), nil, param.Null[int](), param.From(1)}
+	slices.SortFunc(s, param.Compare[int])
+
+	want := []param.Opt[int]{nil, param.Null[int](), param.From(1), param.From(3)}
+	if !slices.EqualFunc(s, want, param.Equal[int]) {
+		t.Errorf("slices.SortFunc() got %v, want %v", s, want)
+	}
+
+	now := time.Now()
+	times := []param.Opt[time.Time]{param.From(now.Add(time.Hour)), param.Null[time.Time](), param.From(now)}
+	slices.SortFunc(times, func(a, b param.Opt[time.Time]) int {
+		return param.CompareFunc(a, b, time.Time.Compare)
+	})
+	if !times[0].IsNull() || !times[1].MustGet().Equal(now) {
+		t.Errorf("slices.SortFunc() with CompareFunc got %v", times)
+	}
+}
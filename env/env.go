@@ -0,0 +1,129 @@
+// Package env loads structs of param.Opt fields from environment variables, so a
+// variable that is not defined leaves its field unset and defaults can be applied
+// only then.
+//
+// Variable names come from `env` tags; fields without one are skipped. A nested
+// struct tagged `env:"DB"` prefixes the names of its fields with "DB_". A variable
+// equal to the null token (param.NullToken by default) sets its field to null, and
+// any other value, including an empty one, is parsed into the field. Slices are
+// read from comma-separated values.
+package env
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+
+	"github.com/qntx/param"
+	"github.com/qntx/param/internal/fielderr"
+	"github.com/qntx/param/internal/optreflect"
+	"github.com/qntx/param/internal/textconv"
+)
+
+// Loader loads environment variables into structs.
+type Loader struct {
+	// Prefix is prepended to every variable name.
+	Prefix string
+	// Null is the value meaning an explicit null. Setting it to "" makes variables
+	// defined as empty null.
+	Null string
+	// Lookup retrieves a variable, reporting whether it is defined. A nil Lookup
+	// reads the process environment with os.LookupEnv.
+	Lookup func(name string) (string, bool)
+}
+
+// NewLoader returns a Loader reading the process environment with param.NullToken
+// as null.
+func NewLoader() *Loader {
+	return &Loader{Null: param.NullToken, Lookup: os.LookupEnv}
+}
+
+// Load is shorthand for NewLoader().Load(dst).
+func Load(dst any) error {
+	return NewLoader().Load(dst)
+}
+
+// Load loads the variables into the struct pointed to by dst. Parse errors do not
+// stop loading; they are all returned as Errors.
+func (l *Loader) Load(dst any) error {
+	v := reflect.ValueOf(dst)
+	if v.Kind() != reflect.Pointer || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("env: dst must be a non-nil pointer to a struct, got %T", dst)
+	}
+	lookup := l.Lookup
+	if lookup == nil {
+		lookup = os.LookupEnv
+	}
+	var errs Errors
+	l.load(&errs, lookup, v.Elem(), l.Prefix)
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+func (l *Loader) load(errs *Errors, lookup func(string) (string, bool), v reflect.Value, prefix string) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		tag, tagged := f.Tag.Lookup("env")
+		name, _, _ := strings.Cut(tag, ",")
+		if name == "-" {
+			continue
+		}
+		fv := v.Field(i)
+
+		if !optreflect.Is(f.Type) && f.Type.Kind() == reflect.Struct && optreflect.IsObject(f.Type) {
+			sub := prefix
+			if name != "" {
+				sub = prefix + name + "_"
+			}
+			l.load(errs, lookup, fv, sub)
+			continue
+		}
+		if !tagged || name == "" {
+			continue
+		}
+
+		name = prefix + name
+		val, ok := lookup(name)
+		if !ok {
+			continue
+		}
+		vals := []string{val}
+		if val != l.Null && isSlice(f.Type) {
+			vals = []string{}
+			if val != "" {
+				vals = strings.Split(val, ",")
+			}
+		}
+		if err := textconv.ParseValues(fv, vals, l.Null); err != nil {
+			*errs = append(*errs, FieldError{Key: name, Err: err})
+		}
+	}
+}
+
+// isSlice reports whether typ, or the type held by the Opt typ, is a slice read
+// from comma-separated values.
+func isSlice(typ reflect.Type) bool {
+	if optreflect.Is(typ) {
+		typ = optreflect.Elem(typ)
+	}
+	return typ.Kind() == reflect.Slice && !textconv.CanParse(typ)
+}
+
+// FieldError describes a variable that could not be parsed. Key is the variable
+// name.
+type FieldError = fielderr.Error
+
+// Errors lists every variable that could not be parsed.
+type Errors = fielderr.List[pkg]
+
+// pkg prefixes the messages of Errors.
+type pkg struct{}
+
+func (pkg) Prefix() string { return "env" }
@@ -0,0 +1,148 @@
+package env_test
+
+import (
+	"errors"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/qntx/param"
+	"github.com/qntx/param/env"
+)
+
+type Database struct {
+	Host param.Opt[string] `env:"HOST"`
+	Port param.Opt[int]    `env:"PORT"`
+}
+
+type Config struct {
+	Name     param.Opt[string]        `env:"NAME"`
+	Debug    param.Opt[bool]          `env:"DEBUG"`
+	Timeout  param.Opt[time.Duration] `env:"TIMEOUT"`
+	Hosts    param.Opt[[]string]      `env:"HOSTS"`
+	Retries  param.Val[int]           `env:"RETRIES"`
+	Workers  int                      `env:"WORKERS"`
+	Database Database                 `env:"DB"`
+	Untagged param.Opt[string]
+	Ignored  param.Opt[string] `env:"-"`
+}
+
+func lookup(vars map[string]string) func(string) (string, bool) {
+	return func(name string) (string, bool) {
+		v, ok := vars[name]
+		return v, ok
+	}
+}
+
+// TestLoad validates the tri-state loading of variables.
+func TestLoad(t *testing.T) {
+	l := env.NewLoader()
+	l.Prefix = "APP_"
+	l.Lookup = lookup(map[string]string{
+		"APP_NAME":    "",
+		"APP_DEBUG":   "null",
+		"APP_TIMEOUT": "5s",
+		"APP_HOSTS":   "a,b",
+		"APP_RETRIES": "3",
+		"APP_WORKERS": "4",
+		"APP_DB_HOST": "localhost",
+		"Untagged":    "x",
+	})
+
+	var got Config
+	if err := l.Load(&got); err != nil {
+		t.Fatalf("Load() returned an unexpected error: %v", err)
+	}
+
+	want := Config{
+		Name:     param.From(""),
+		Debug:    param.Null[bool](),
+		Timeout:  param.From(5 * time.Second),
+		Hosts:    param.From([]string{"a", "b"}),
+		Retries:  param.ValOf(3),
+		Workers:  4,
+		Database: Database{Host: param.From("localhost")},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Load() got %+v, want %+v", got, want)
+	}
+	if got.Database.Port.IsSet() {
+		t.Error("Load() should leave undefined variables unset")
+	}
+}
+
+// TestLoadEmpty validates that variables defined as empty are parsed, giving
+// empty slices, with the default null token.
+func TestLoadEmpty(t *testing.T) {
+	l := env.NewLoader()
+	l.Lookup = lookup(map[string]string{"NAME": "", "HOSTS": ""})
+
+	var got Config
+	if err := l.Load(&got); err != nil {
+		t.Fatalf("Load() returned an unexpected error: %v", err)
+	}
+	if !param.Equal(got.Name, param.From("")) {
+		t.Errorf("Load() got NAME=%v, want an empty value", got.Name)
+	}
+	if hosts, ok := got.Hosts.Get(); !ok || hosts == nil || len(hosts) != 0 {
+		t.Errorf("Load() got HOSTS=%#v, want an empty slice", got.Hosts)
+	}
+}
+
+// TestLoaderNilLookup validates that a Loader without Lookup reads the process
+// environment.
+func TestLoaderNilLookup(t *testing.T) {
+	t.Setenv("PARAM_TEST_NAME", "Alice")
+
+	var got Config
+	l := env.Loader{Prefix: "PARAM_TEST_"}
+	if err := l.Load(&got); err != nil {
+		t.Fatalf("Load() returned an unexpected error: %v", err)
+	}
+	if !param.Equal(got.Name, param.From("Alice")) {
+		t.Errorf("Load() got NAME=%v, want Alice", got.Name)
+	}
+}
+
+// TestLoaderNull validates that an empty null token makes empty variables null.
+func TestLoaderNull(t *testing.T) {
+	l := env.NewLoader()
+	l.Null = ""
+	l.Lookup = lookup(map[string]string{"NAME": "", "HOSTS": "", "DEBUG": "null"})
+
+	var got Config
+	if err := l.Load(&got); err == nil {
+		t.Fatal("Expected a parse error for DEBUG but got nil")
+	}
+	if !got.Name.IsNull() || !got.Hosts.IsNull() {
+		t.Errorf("Load() got NAME=%v HOSTS=%v, want both null", got.Name, got.Hosts)
+	}
+}
+
+// TestLoadErrors validates that every parse error is reported.
+func TestLoadErrors(t *testing.T) {
+	l := env.NewLoader()
+	l.Lookup = lookup(map[string]string{"DEBUG": "maybe", "DB_PORT": "x", "WORKERS": "2"})
+
+	var got Config
+	err := l.Load(&got)
+
+	var errs env.Errors
+	if !errors.As(err, &errs) {
+		t.Fatalf("Load() error got %v, want env.Errors", err)
+	}
+	if len(errs) != 2 || errs[0].Key != "DEBUG" || errs[1].Key != "DB_PORT" {
+		t.Errorf("Load() errors got %v, want errors for DEBUG and DB_PORT", errs)
+	}
+	if !errors.Is(err, strconv.ErrSyntax) {
+		t.Errorf("Load() error %v should wrap strconv.ErrSyntax", err)
+	}
+	if got.Workers != 2 {
+		t.Error("Load() should keep loading after a parse error")
+	}
+
+	if err := env.Load(Config{}); err == nil {
+		t.Error("Expected an error for a non-pointer destination but got nil")
+	}
+}
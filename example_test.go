@@ -403,3 +403,15 @@ func ExampleNull_unmarshalOptional() {
 	// obj.Name.MustGet(): "foo"
 	// ---
 }
+
+func ExampleMap() {
+	length := func(s string) int { return len(s) }
+
+	fmt.Println(param.Map(param.From("Alice"), length).Get())
+	fmt.Println(param.Map(param.Null[string](), length).IsNull())
+	fmt.Println(param.Map(param.Zero[string](), length).IsSet())
+	// Output:
+	// 5 true
+	// true
+	// false
+}
@@ -0,0 +1,248 @@
+// Package fieldmask converts between structs of param.Opt fields and field masks,
+// the lists of dotted paths such as "address.city" used by update_mask style APIs
+// to say which fields an update overwrites. Path segments are `json` names.
+package fieldmask
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/qntx/param/internal/optreflect"
+	"github.com/qntx/param/mergepatch"
+)
+
+// Paths returns the path of every Opt field of patch, a struct or a pointer to
+// one, that is set to null or to a value, in field order. Nested structs holding
+// Opt fields, whether plain fields, pointers or Opt values, contribute the paths
+// of their own set fields instead of their own path.
+func Paths(patch any) ([]string, error) {
+	v := reflect.ValueOf(patch)
+	for v.Kind() == reflect.Pointer && !v.IsNil() {
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("fieldmask: patch must be a struct, got %T", patch)
+	}
+	addr := optreflect.Addressable(v)
+	return paths(nil, "", addr), nil
+}
+
+func paths(out []string, prefix string, v reflect.Value) []string {
+	for _, f := range optreflect.Fields(v.Type(), "json") {
+		fv := v.FieldByIndex(f.Index)
+		path := prefix + f.Key
+		switch {
+		case optreflect.Is(f.Type):
+			if !optreflect.State(fv).IsSet() {
+				continue
+			}
+			val, ok := optreflect.Get(fv)
+			if ok && patchType(val.Type()) {
+				addr := optreflect.Addressable(val)
+				out = paths(out, path+".", addr)
+				continue
+			}
+			out = append(out, path)
+		case f.Type.Kind() == reflect.Pointer && patchType(f.Type.Elem()):
+			if !fv.IsNil() {
+				out = paths(out, path+".", fv.Elem())
+			}
+		case patchType(f.Type):
+			out = paths(out, path+".", fv)
+		}
+	}
+	return out
+}
+
+// patchType reports whether typ is a struct with Opt fields, possibly nested.
+func patchType(typ reflect.Type) bool {
+	return hasOpt(typ, map[reflect.Type]bool{})
+}
+
+func hasOpt(typ reflect.Type, seen map[reflect.Type]bool) bool {
+	if typ.Kind() != reflect.Struct || !optreflect.IsObject(typ) || seen[typ] {
+		return false
+	}
+	seen[typ] = true
+	for _, f := range optreflect.Fields(typ, "json") {
+		ft := f.Type
+		if ft.Kind() == reflect.Pointer {
+			ft = ft.Elem()
+		}
+		if optreflect.Is(ft) || hasOpt(ft, seen) {
+			return true
+		}
+	}
+	return false
+}
+
+// Apply overwrites the fields of the struct pointed to by dst listed in paths with
+// the matching fields of src, a struct or a pointer to one. dst and src may have
+// different types, such as a domain struct and its patch struct, as long as every
+// path exists in both; otherwise an error listing the unknown paths is returned
+// and dst is left untouched.
+//
+// Following update_mask semantics, a listed field is overwritten whatever its
+// state in src: a value replaces the target, converting between compatible types
+// and merging a patch struct into a cleared target, while a null or unset field,
+// or one below a null, unset or nil field, clears it. Cleared Opt fields take the
+// state of src (null or unset), and other fields their zero value. Nil pointers
+// and unset Opt values on the way to a target field are allocated.
+func Apply(dst, src any, paths []string) error {
+	dv := reflect.ValueOf(dst)
+	if dv.Kind() != reflect.Pointer || dv.IsNil() || dv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("fieldmask: dst must be a non-nil pointer to a struct, got %T", dst)
+	}
+	sv := reflect.ValueOf(src)
+	for sv.Kind() == reflect.Pointer && !sv.IsNil() {
+		sv = sv.Elem()
+	}
+	if sv.Kind() != reflect.Struct {
+		return fmt.Errorf("fieldmask: src must be a struct, got %T", src)
+	}
+
+	var unknown []string
+	for _, path := range paths {
+		keys := strings.Split(path, ".")
+		if !valid(dv.Elem().Type(), keys) || !valid(sv.Type(), keys) {
+			unknown = append(unknown, fmt.Sprintf("%q", path))
+		}
+	}
+	if len(unknown) > 0 {
+		return fmt.Errorf("fieldmask: unknown paths %s", strings.Join(unknown, ", "))
+	}
+
+	addr := optreflect.Addressable(sv)
+	for _, path := range paths {
+		keys := strings.Split(path, ".")
+		val, null := lookup(addr, keys)
+		if err := store(dv.Elem(), keys, val, null); err != nil {
+			return fmt.Errorf("fieldmask: path %q: %w", path, err)
+		}
+	}
+	return nil
+}
+
+// field returns the index of the field of the struct typ named key.
+func field(typ reflect.Type, key string) ([]int, bool) {
+	for _, f := range optreflect.Fields(typ, "json") {
+		if f.Key == key {
+			return f.Index, true
+		}
+	}
+	return nil, false
+}
+
+// valid reports whether the path keys exists in the struct typ.
+func valid(typ reflect.Type, keys []string) bool {
+	for _, key := range keys {
+		for {
+			if optreflect.Is(typ) {
+				typ = optreflect.Elem(typ)
+			} else if typ.Kind() == reflect.Pointer {
+				typ = typ.Elem()
+			} else {
+				break
+			}
+		}
+		if typ.Kind() != reflect.Struct {
+			return false
+		}
+		index, ok := field(typ, key)
+		if !ok {
+			return false
+		}
+		typ = typ.FieldByIndex(index).Type
+	}
+	return true
+}
+
+// lookup returns the value at the path keys of the addressable struct v. The
+// value is invalid if the path reaches an unset field, or a null or nil one in
+// which case null is true.
+func lookup(v reflect.Value, keys []string) (val reflect.Value, null bool) {
+	for _, key := range keys {
+		index, _ := field(v.Type(), key)
+		v = v.FieldByIndex(index)
+		for {
+			if optreflect.Is(v.Type()) {
+				opt := optreflect.State(v)
+				if !opt.IsSet() || opt.IsNull() {
+					return reflect.Value{}, opt.IsNull()
+				}
+				val, _ := optreflect.Get(v)
+				v = optreflect.Addressable(val)
+			} else if v.Kind() == reflect.Pointer {
+				if v.IsNil() {
+					return reflect.Value{}, true
+				}
+				v = v.Elem()
+			} else {
+				break
+			}
+		}
+	}
+	return v, false
+}
+
+// store overwrites the field at the path keys of the struct d with val, or clears
+// it if val is invalid.
+func store(d reflect.Value, keys []string, val reflect.Value, null bool) error {
+	index, _ := field(d.Type(), keys[0])
+	slot := d.FieldByIndex(index)
+	if len(keys) > 1 {
+		return descend(slot, keys[1:], val, null)
+	}
+
+	if !val.IsValid() {
+		switch {
+		case optreflect.Is(slot.Type()) && null:
+			optreflect.State(slot).SetNull()
+		case optreflect.Is(slot.Type()):
+			optreflect.State(slot).Reset()
+		default:
+			slot.Set(reflect.Zero(slot.Type()))
+		}
+		return nil
+	}
+	if val.Type().AssignableTo(slot.Type()) {
+		slot.Set(val)
+		return nil
+	}
+	if optreflect.IsObject(val.Type()) {
+		slot.Set(reflect.Zero(slot.Type()))
+		return mergepatch.Apply(slot.Addr().Interface(), val.Interface())
+	}
+	return optreflect.Assign(slot, val)
+}
+
+// descend stores val at the path keys below the target d, allocating nil pointers
+// and unset Opt values unless the target is only cleared.
+func descend(d reflect.Value, keys []string, val reflect.Value, null bool) error {
+	switch {
+	case optreflect.Is(d.Type()):
+		cur, ok := optreflect.Get(d)
+		if !ok && !val.IsValid() {
+			return nil
+		}
+		tmp := reflect.New(optreflect.Elem(d.Type())).Elem()
+		if ok {
+			tmp.Set(cur)
+		}
+		if err := descend(tmp, keys, val, null); err != nil {
+			return err
+		}
+		optreflect.Set(d, tmp)
+		return nil
+	case d.Kind() == reflect.Pointer:
+		if d.IsNil() {
+			if !val.IsValid() {
+				return nil
+			}
+			d.Set(reflect.New(d.Type().Elem()))
+		}
+		return descend(d.Elem(), keys, val, null)
+	}
+	return store(d, keys, val, null)
+}
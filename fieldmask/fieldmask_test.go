@@ -0,0 +1,203 @@
+package fieldmask_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/qntx/param"
+	"github.com/qntx/param/fieldmask"
+)
+
+type Address struct {
+	City string `json:"city"`
+	Zip  string `json:"zip"`
+}
+
+type User struct {
+	Name    string            `json:"name"`
+	Email   *string           `json:"email"`
+	Nick    param.Opt[string] `json:"nick"`
+	Age     int               `json:"age"`
+	Address Address           `json:"address"`
+	Billing *Address          `json:"billing"`
+}
+
+type AddressPatch struct {
+	City param.Opt[string] `json:"city"`
+	Zip  param.Opt[string] `json:"zip"`
+}
+
+type UserPatch struct {
+	Name    param.Opt[string]       `json:"name"`
+	Email   param.Opt[string]       `json:"email"`
+	Nick    param.Opt[string]       `json:"nick"`
+	Age     param.Opt[int64]        `json:"age"`
+	Address param.Opt[AddressPatch] `json:"address"`
+	Billing param.Opt[AddressPatch] `json:"billing"`
+	Version int                     `json:"version"`
+}
+
+func user() User {
+	return User{
+		Name:    "Alice",
+		Email:   param.Ptr("alice@example.com"),
+		Nick:    param.From("al"),
+		Age:     30,
+		Address: Address{City: "Paris", Zip: "75001"},
+		Billing: &Address{City: "Lyon", Zip: "69001"},
+	}
+}
+
+// TestPaths validates the paths of set and null fields.
+func TestPaths(t *testing.T) {
+	testCases := []struct {
+		name  string
+		patch any
+		want  []string
+	}{
+		{name: "Empty", patch: UserPatch{Version: 2}, want: nil},
+		{
+			name: "Values and nulls",
+			patch: &UserPatch{
+				Name:    param.From("Bob"),
+				Email:   param.Null[string](),
+				Address: param.From(AddressPatch{City: param.From("Rome")}),
+				Billing: param.Null[AddressPatch](),
+			},
+			want: []string{"name", "email", "address.city", "billing"},
+		},
+		{
+			name: "Plain nested struct",
+			patch: struct {
+				Inner AddressPatch  `json:"inner"`
+				Ptr   *AddressPatch `json:"ptr"`
+			}{Inner: AddressPatch{Zip: param.Null[string]()}},
+			want: []string{"inner.zip"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := fieldmask.Paths(tc.patch)
+			if err != nil {
+				t.Fatalf("Paths() returned an unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("Paths() got %q, want %q", got, tc.want)
+			}
+		})
+	}
+
+	if _, err := fieldmask.Paths(42); err == nil {
+		t.Error("Expected an error for a non-struct patch but got nil")
+	}
+}
+
+// TestApply validates update_mask semantics.
+func TestApply(t *testing.T) {
+	patch := UserPatch{
+		Name:    param.From("Bob"),
+		Email:   param.Null[string](),
+		Nick:    param.From("bobby"),
+		Age:     param.Zero[int64](),
+		Address: param.From(AddressPatch{City: param.From("Rome")}),
+		Billing: param.From(AddressPatch{Zip: param.From("00100")}),
+	}
+
+	testCases := []struct {
+		name  string
+		paths []string
+		want  func(u *User)
+	}{
+		{
+			name:  "Only listed fields",
+			paths: []string{"name", "address.city"},
+			want: func(u *User) {
+				u.Name = "Bob"
+				u.Address.City = "Rome"
+			},
+		},
+		{
+			name:  "Null and unset clear",
+			paths: []string{"email", "age"},
+			want: func(u *User) {
+				u.Email = nil
+				u.Age = 0
+			},
+		},
+		{
+			name:  "Whole object replaced",
+			paths: []string{"billing"},
+			want: func(u *User) {
+				u.Billing = &Address{Zip: "00100"}
+			},
+		},
+		{
+			name:  "Unset below a value clears",
+			paths: []string{"nick", "address.zip"},
+			want: func(u *User) {
+				u.Nick = param.From("bobby")
+				u.Address.Zip = ""
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, want := user(), user()
+			tc.want(&want)
+			if err := fieldmask.Apply(&got, patch, tc.paths); err != nil {
+				t.Fatalf("Apply() returned an unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("Apply() got %+v, want %+v", got, want)
+			}
+		})
+	}
+
+	t.Run("Allocates nil targets", func(t *testing.T) {
+		var got User
+		if err := fieldmask.Apply(&got, patch, []string{"billing.zip", "nick"}); err != nil {
+			t.Fatalf("Apply() returned an unexpected error: %v", err)
+		}
+		want := User{Billing: &Address{Zip: "00100"}, Nick: param.From("bobby")}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("Apply() got %+v, want %+v", got, want)
+		}
+	})
+
+	t.Run("Same type", func(t *testing.T) {
+		got, src := user(), User{Address: Address{Zip: "10115"}}
+		if err := fieldmask.Apply(&got, &src, []string{"address.zip", "billing"}); err != nil {
+			t.Fatalf("Apply() returned an unexpected error: %v", err)
+		}
+		want := user()
+		want.Address.Zip = "10115"
+		want.Billing = nil
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("Apply() got %+v, want %+v", got, want)
+		}
+	})
+}
+
+// TestApplyErrors validates that unknown paths are reported without side effects.
+func TestApplyErrors(t *testing.T) {
+	got := user()
+	err := fieldmask.Apply(&got, UserPatch{Name: param.From("Bob")}, []string{"name", "nmae", "address.street", "version"})
+	if err == nil {
+		t.Fatal("Expected an unknown path error but got nil")
+	}
+	for _, path := range []string{`"nmae"`, `"address.street"`, `"version"`} {
+		if !strings.Contains(err.Error(), path) {
+			t.Errorf("Apply() error %q should mention %s", err, path)
+		}
+	}
+	if !reflect.DeepEqual(got, user()) {
+		t.Error("Apply() should leave dst untouched on unknown paths")
+	}
+
+	if err := fieldmask.Apply(user(), UserPatch{}, nil); err == nil {
+		t.Error("Expected an error for a non-pointer destination but got nil")
+	}
+}
@@ -0,0 +1,82 @@
+package param
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Ensure Opt and Val implement fmt.Stringer, fmt.GoStringer and fmt.Formatter
+var _ fmt.Stringer = Opt[any](nil)
+var _ fmt.GoStringer = Opt[any](nil)
+var _ fmt.Formatter = Opt[any](nil)
+var _ fmt.Stringer = Val[any]{}
+var _ fmt.GoStringer = Val[any]{}
+var _ fmt.Formatter = Val[any]{}
+
+// String implements fmt.Stringer, returning the value formatted with %v, NullToken
+// for null or UnsetMarker for unset.
+func (t Opt[T]) String() string {
+	return fmt.Sprint(t)
+}
+
+// GoString implements fmt.GoStringer, returning the Go expression building t:
+// param.From(v), param.Null[T]() or param.Zero[T]().
+func (t Opt[T]) GoString() string {
+	if v, ok := t.Get(); ok {
+		return fmt.Sprintf("param.From(%#v)", v)
+	}
+	if t.IsNull() {
+		return "param.Null[" + typeName[T]() + "]()"
+	}
+	return "param.Zero[" + typeName[T]() + "]()"
+}
+
+// Format implements fmt.Formatter. A value is formatted with the verb and flags
+// as if it were passed directly, so %q quotes a string and %+v prints field
+// names. Null and unset are written as NullToken and UnsetMarker whatever the
+// verb, and %#v uses GoString.
+func (t Opt[T]) Format(f fmt.State, verb rune) {
+	if verb == 'v' && f.Flag('#') {
+		fmt.Fprint(f, t.GoString())
+		return
+	}
+	if v, ok := t.Get(); ok {
+		fmt.Fprintf(f, fmt.FormatString(f, verb), v)
+		return
+	}
+	marker := UnsetMarker
+	if t.IsNull() {
+		marker = NullToken
+	}
+	fmt.Fprintf(f, fmt.FormatString(f, 's'), marker)
+}
+
+// String implements fmt.Stringer like Opt.String.
+func (t Val[T]) String() string {
+	return fmt.Sprint(t)
+}
+
+// GoString implements fmt.GoStringer, returning the Go expression building t:
+// param.ValOf(v), param.NullVal[T]() or param.Val[T]{}.
+func (t Val[T]) GoString() string {
+	if v, ok := t.Get(); ok {
+		return fmt.Sprintf("param.ValOf(%#v)", v)
+	}
+	if t.IsNull() {
+		return "param.NullVal[" + typeName[T]() + "]()"
+	}
+	return "param.Val[" + typeName[T]() + "]{}"
+}
+
+// Format implements fmt.Formatter like Opt.Format.
+func (t Val[T]) Format(f fmt.State, verb rune) {
+	if verb == 'v' && f.Flag('#') {
+		fmt.Fprint(f, t.GoString())
+		return
+	}
+	t.Opt().Format(f, verb)
+}
+
+func typeName[T any]() string {
+	return reflect.TypeOf((*T)(nil)).Elem().String()
+}
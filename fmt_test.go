@@ -0,0 +1,74 @@
+package param_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/qntx/param"
+)
+
+// TestFormat validates the formatting of each state with common verbs.
+func TestFormat(t *testing.T) {
+	type point struct{ X, Y int }
+
+	testCases := []struct {
+		name   string
+		format string
+		input  any
+		want   string
+	}{
+		{name: "Value %v", format: "%v", input: param.From("Alice"), want: "Alice"},
+		{name: "Value %q", format: "%q", input: param.From("Alice"), want: `"Alice"`},
+		{name: "Value %+v", format: "%+v", input: param.From(point{1, 2}), want: "{X:1 Y:2}"},
+		{name: "Value %05d", format: "%05d", input: param.From(42), want: "00042"},
+		{name: "Value %.1f", format: "%.1f", input: param.From(1.25), want: "1.2"},
+		{name: "Null %v", format: "%v", input: param.Null[string](), want: "null"},
+		{name: "Null %q", format: "%q", input: param.Null[string](), want: "null"},
+		{name: "Unset %d", format: "%d", input: param.Zero[int](), want: "<unset>"},
+		{name: "Width", format: "[%6v]", input: param.Null[int](), want: "[  null]"},
+		{name: "Val %v", format: "%v", input: param.ValOf(7), want: "7"},
+		{name: "Val unset", format: "%v", input: param.Val[int]{}, want: "<unset>"},
+		{name: "Nested %+v", format: "%+v", input: struct {
+			Name param.Opt[string]
+			Age  param.Opt[int]
+		}{Name: param.From("Bob"), Age: param.Null[int]()}, want: "{Name:Bob Age:null}"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := fmt.Sprintf(tc.format, tc.input); got != tc.want {
+				t.Errorf("Sprintf(%q) got %q, want %q", tc.format, got, tc.want)
+			}
+		})
+	}
+}
+
+// TestGoString validates that %#v prints the Go expression building each state.
+func TestGoString(t *testing.T) {
+	testCases := []struct {
+		name  string
+		input any
+		want  string
+	}{
+		{name: "Value", input: param.From("Alice"), want: `param.From("Alice")`},
+		{name: "Null", input: param.Null[int](), want: "param.Null[int]()"},
+		{name: "Unset", input: param.Zero[time.Time](), want: "param.Zero[time.Time]()"},
+		{name: "Nil map", input: param.Opt[[]string](nil), want: "param.Zero[[]string]()"},
+		{name: "Val", input: param.ValOf(int64(3)), want: "param.ValOf(3)"},
+		{name: "Val null", input: param.NullVal[bool](), want: "param.NullVal[bool]()"},
+		{name: "Val unset", input: param.Val[string]{}, want: "param.Val[string]{}"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := fmt.Sprintf("%#v", tc.input); got != tc.want {
+				t.Errorf("Sprintf(%%#v) got %s, want %s", got, tc.want)
+			}
+		})
+	}
+
+	if got := param.From(42).String(); got != "42" {
+		t.Errorf("String() got %q, want %q", got, "42")
+	}
+}
@@ -0,0 +1,108 @@
+package form
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"strings"
+
+	"github.com/qntx/param"
+	"github.com/qntx/param/internal/optreflect"
+	"github.com/qntx/param/internal/textconv"
+)
+
+// Decoder decodes url.Values into structs.
+type Decoder struct {
+	// Tag is the struct tag holding parameter names, falling back to `json`.
+	Tag string
+	// Null is the parameter value meaning an explicit null. Setting it to "" makes
+	// empty parameters null.
+	Null string
+}
+
+// NewDecoder returns a Decoder reading the `form` tag and param.NullToken as null.
+func NewDecoder() *Decoder {
+	return &Decoder{Tag: "form", Null: param.NullToken}
+}
+
+// Decode is shorthand for NewDecoder().Decode(values, dst).
+func Decode(values url.Values, dst any) error {
+	return NewDecoder().Decode(values, dst)
+}
+
+// Decode decodes values into the struct pointed to by dst. Nested structs, including
+// Opt ones, are decoded from dotted parameters such as "address.city", mirroring
+// Encode; an Opt struct is set when one of its parameters is present and becomes
+// null from the null value under its own key. Conversion errors do not stop
+// decoding; they are all returned as Errors.
+func (d *Decoder) Decode(values url.Values, dst any) error {
+	v := reflect.ValueOf(dst)
+	if v.Kind() != reflect.Pointer || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("form: dst must be a non-nil pointer to a struct, got %T", dst)
+	}
+	var errs Errors
+	d.decode(&errs, values, v.Elem(), "")
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+func (d *Decoder) decode(errs *Errors, values url.Values, v reflect.Value, prefix string) {
+	for _, f := range optreflect.Fields(v.Type(), d.Tag, "json") {
+		fv, err := v.FieldByIndexErr(f.Index)
+		if err != nil {
+			continue
+		}
+		key := prefix + f.Key
+		vals, ok := values[key]
+		switch {
+		case nested(f.Type):
+			d.decode(errs, values, fv, key+".")
+			continue
+		case optreflect.Is(f.Type) && nested(optreflect.Elem(f.Type)) && !d.null(vals):
+			d.decodeOpt(errs, values, fv, key)
+			continue
+		case !ok || len(vals) == 0:
+			continue
+		}
+		if err := textconv.ParseValues(fv, vals, d.Null); err != nil {
+			*errs = append(*errs, FieldError{Key: key, Err: err})
+		}
+	}
+}
+
+// decodeOpt decodes the parameters below key into the Opt struct v, setting it
+// only if at least one of them is present. A set v is decoded into, so its other
+// fields are kept.
+func (d *Decoder) decodeOpt(errs *Errors, values url.Values, v reflect.Value, key string) {
+	if !hasPrefix(values, key+".") {
+		return
+	}
+	tmp := reflect.New(optreflect.Elem(v.Type())).Elem()
+	if cur, ok := optreflect.Get(v); ok {
+		tmp.Set(cur)
+	}
+	d.decode(errs, values, tmp, key+".")
+	optreflect.Set(v, tmp)
+}
+
+// null reports whether vals is the single value d.Null.
+func (d *Decoder) null(vals []string) bool {
+	return len(vals) == 1 && vals[0] == d.Null
+}
+
+// nested reports whether typ is a struct decoded from dotted parameters.
+func nested(typ reflect.Type) bool {
+	return typ.Kind() == reflect.Struct && optreflect.IsObject(typ)
+}
+
+// hasPrefix reports whether values holds a non-empty parameter starting with prefix.
+func hasPrefix(values url.Values, prefix string) bool {
+	for key, vals := range values {
+		if len(vals) > 0 && strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,131 @@
+package form_test
+
+import (
+	"errors"
+	"net/url"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/qntx/param"
+	"github.com/qntx/param/form"
+)
+
+type Address struct {
+	City param.Opt[string] `form:"city"`
+}
+
+type Filter struct {
+	Name    param.Opt[string]    `form:"name"`
+	Age     param.Opt[int]       `json:"age"`
+	Tags    param.Opt[[]string]  `form:"tag"`
+	IDs     param.Opt[[]int]     `form:"id"`
+	Since   param.Opt[time.Time] `form:"since"`
+	Active  param.Val[bool]      `form:"active"`
+	Page    int                  `form:"page"`
+	Limit   *int                 `form:"limit"`
+	Address Address              `form:"address"`
+	Ignored param.Opt[string]    `form:"-"`
+}
+
+// TestDecode validates the tri-state decoding of parameters.
+func TestDecode(t *testing.T) {
+	since := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+
+	values := url.Values{
+		"name":         {""},
+		"age":          {"null"},
+		"tag":          {"a", "b"},
+		"id":           {"1", "2", "3"},
+		"since":        {since.Format(time.RFC3339)},
+		"active":       {"true"},
+		"page":         {"2"},
+		"limit":        {"50"},
+		"address.city": {"Paris"},
+		"Ignored":      {"x"},
+	}
+
+	var got Filter
+	if err := form.Decode(values, &got); err != nil {
+		t.Fatalf("Decode() returned an unexpected error: %v", err)
+	}
+
+	want := Filter{
+		Name:    param.From(""),
+		Age:     param.Null[int](),
+		Tags:    param.From([]string{"a", "b"}),
+		IDs:     param.From([]int{1, 2, 3}),
+		Since:   param.From(since),
+		Active:  param.ValOf(true),
+		Page:    2,
+		Limit:   param.Ptr(50),
+		Address: Address{City: param.From("Paris")},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Decode() got %+v, want %+v", got, want)
+	}
+}
+
+// TestDecodeAbsent validates that absent parameters leave fields unset.
+func TestDecodeAbsent(t *testing.T) {
+	var got Filter
+	if err := form.Decode(url.Values{}, &got); err != nil {
+		t.Fatalf("Decode() returned an unexpected error: %v", err)
+	}
+	if got.Name.IsSet() || got.Age.IsSet() || got.Tags.IsSet() || got.Active.IsSet() || got.Address.City.IsSet() {
+		t.Errorf("Decode() got %+v, want every Opt unset", got)
+	}
+
+	t.Run("Empty value lists", func(t *testing.T) {
+		var got Filter
+		values := url.Values{"name": {}, "tag": {}, "page": {}, "limit": {}, "address.city": {}}
+		if err := form.Decode(values, &got); err != nil {
+			t.Fatalf("Decode() returned an unexpected error: %v", err)
+		}
+		if !reflect.DeepEqual(got, Filter{}) {
+			t.Errorf("Decode() got %+v, want every field untouched", got)
+		}
+	})
+}
+
+// TestDecoderNull validates a custom null token.
+func TestDecoderNull(t *testing.T) {
+	d := form.NewDecoder()
+	d.Null = ""
+
+	var got Filter
+	if err := d.Decode(url.Values{"name": {""}, "age": {"null"}, "tag": {""}}, &got); err == nil {
+		t.Fatal("Expected a conversion error for age but got nil")
+	}
+	if !got.Name.IsNull() || !got.Tags.IsNull() {
+		t.Errorf("Decode() got name=%v tag=%v, want both null", got.Name, got.Tags)
+	}
+	if v, _ := got.Age.Get(); got.Age.IsSet() {
+		t.Errorf("Decode() got age=%v, want unset", v)
+	}
+}
+
+// TestDecodeErrors validates that every conversion error is reported.
+func TestDecodeErrors(t *testing.T) {
+	var got Filter
+	err := form.Decode(url.Values{"age": {"x"}, "id": {"1", "y"}, "page": {"2"}}, &got)
+
+	var errs form.Errors
+	if !errors.As(err, &errs) {
+		t.Fatalf("Decode() error got %v, want form.Errors", err)
+	}
+	if len(errs) != 2 || errs[0].Key != "age" || errs[1].Key != "id" {
+		t.Errorf("Decode() errors got %v, want errors for age and id", errs)
+	}
+	if !errors.Is(err, strconv.ErrSyntax) {
+		t.Errorf("Decode() error %v should wrap strconv.ErrSyntax", err)
+	}
+	if got.Page != 2 {
+		t.Error("Decode() should keep decoding after a conversion error")
+	}
+
+	if err := form.Decode(url.Values{}, Filter{}); err == nil {
+		t.Error("Expected an error for a non-pointer destination but got nil")
+	}
+}
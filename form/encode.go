@@ -0,0 +1,111 @@
+package form
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"slices"
+
+	"github.com/qntx/param"
+	"github.com/qntx/param/internal/optreflect"
+	"github.com/qntx/param/internal/textconv"
+)
+
+// Encoder encodes structs into url.Values.
+type Encoder struct {
+	// Tag is the struct tag holding parameter names, falling back to `json`.
+	Tag string
+	// Null is the parameter value written for an explicit null.
+	Null string
+}
+
+// NewEncoder returns an Encoder reading the `form` tag and writing param.NullToken
+// for null.
+func NewEncoder() *Encoder {
+	return &Encoder{Tag: "form", Null: param.NullToken}
+}
+
+// Encode is shorthand for NewEncoder().Encode(src).
+func Encode(src any) (url.Values, error) {
+	return NewEncoder().Encode(src)
+}
+
+// Encode encodes src, a struct or a pointer to one, into url.Values. Unset Opt
+// fields and nil pointers are omitted, null fields are written as e.Null, and values
+// are formatted with encoding.TextMarshaler or strconv, slices as repeated
+// parameters. Plain fields are always written unless tagged `omitempty` and zero.
+func (e *Encoder) Encode(src any) (url.Values, error) {
+	v := reflect.ValueOf(src)
+	for v.Kind() == reflect.Pointer && !v.IsNil() {
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("form: src must be a struct, got %T", src)
+	}
+
+	addr := optreflect.Addressable(v)
+
+	values := url.Values{}
+	var errs Errors
+	e.encode(&errs, values, addr, "")
+	if len(errs) > 0 {
+		return nil, errs
+	}
+	return values, nil
+}
+
+func (e *Encoder) encode(errs *Errors, values url.Values, v reflect.Value, prefix string) {
+	for _, f := range optreflect.Fields(v.Type(), e.Tag, "json") {
+		fv, err := v.FieldByIndexErr(f.Index)
+		if err != nil {
+			continue
+		}
+		key := prefix + f.Key
+		switch {
+		case optreflect.Is(f.Type):
+			opt := optreflect.State(fv)
+			if !opt.IsSet() {
+				continue
+			}
+			if opt.IsNull() {
+				values.Set(key, e.Null)
+				continue
+			}
+			val, _ := optreflect.Get(fv)
+			fv = optreflect.Addressable(val)
+		case slices.Contains(f.Opts, "omitempty") && fv.IsZero():
+			continue
+		}
+		if fv.Kind() == reflect.Struct && optreflect.IsObject(fv.Type()) {
+			e.encode(errs, values, fv, key+".")
+			continue
+		}
+		if err := addValues(values, key, fv); err != nil {
+			*errs = append(*errs, FieldError{Key: key, Err: err})
+		}
+	}
+}
+
+// addValues formats v under key, adding one parameter per element of slices.
+func addValues(values url.Values, key string, v reflect.Value) error {
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+	if (v.Kind() == reflect.Slice || v.Kind() == reflect.Array) && !textconv.CanFormat(v.Type()) {
+		for i := 0; i < v.Len(); i++ {
+			if err := addValues(values, key, v.Index(i)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	s, err := textconv.Format(v.Interface())
+	if err != nil {
+		return err
+	}
+	values.Add(key, s)
+	return nil
+}
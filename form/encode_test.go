@@ -0,0 +1,180 @@
+package form_test
+
+import (
+	"net"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/qntx/param"
+	"github.com/qntx/param/form"
+)
+
+type Paging struct {
+	Page  int `form:"page,omitempty"`
+	Limit int `form:"limit"`
+}
+
+type Query struct {
+	Name    param.Opt[string]    `form:"name"`
+	Age     param.Opt[int]       `form:"age"`
+	Tags    param.Opt[[]string]  `form:"tag"`
+	Since   param.Opt[time.Time] `form:"since"`
+	IP      param.Opt[net.IP]    `form:"ip"`
+	Active  param.Val[bool]      `form:"active"`
+	Address param.Opt[Address]   `form:"address"`
+	Cursor  *string              `form:"cursor"`
+	Paging
+}
+
+// TestEncode validates the tri-state encoding of parameters.
+func TestEncode(t *testing.T) {
+	since := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name  string
+		input Query
+		want  url.Values
+	}{
+		{
+			name:  "Unset fields are omitted",
+			input: Query{},
+			want:  url.Values{"limit": {"0"}},
+		},
+		{
+			name: "Values are formatted",
+			input: Query{
+				Name:    param.From(""),
+				Age:     param.From(30),
+				Tags:    param.From([]string{"a", "b"}),
+				Since:   param.From(since),
+				IP:      param.From(net.IPv4(10, 0, 0, 1)),
+				Active:  param.ValOf(false),
+				Address: param.From(Address{City: param.From("Paris")}),
+				Cursor:  param.Ptr("abc"),
+				Paging:  Paging{Page: 2, Limit: 10},
+			},
+			want: url.Values{
+				"name":         {""},
+				"age":          {"30"},
+				"tag":          {"a", "b"},
+				"since":        {"2024-01-02T00:00:00Z"},
+				"ip":           {"10.0.0.1"},
+				"active":       {"false"},
+				"address.city": {"Paris"},
+				"cursor":       {"abc"},
+				"page":         {"2"},
+				"limit":        {"10"},
+			},
+		},
+		{
+			name:  "Null fields are written as the null token",
+			input: Query{Name: param.Null[string](), Tags: param.Null[[]string](), Active: param.NullVal[bool]()},
+			want:  url.Values{"name": {"null"}, "tag": {"null"}, "active": {"null"}, "limit": {"0"}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := form.Encode(&tc.input)
+			if err != nil {
+				t.Fatalf("Encode() returned an unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("Encode() got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+// TestEncoderNull validates a custom null representation.
+func TestEncoderNull(t *testing.T) {
+	e := form.NewEncoder()
+	e.Null = ""
+
+	got, err := e.Encode(Query{Age: param.Null[int]()})
+	if err != nil {
+		t.Fatalf("Encode() returned an unexpected error: %v", err)
+	}
+	if want := (url.Values{"age": {""}, "limit": {"0"}}); !reflect.DeepEqual(got, want) {
+		t.Errorf("Encode() got %v, want %v", got, want)
+	}
+}
+
+// TestEncodeRoundTrip validates that Decode reverses Encode.
+func TestEncodeRoundTrip(t *testing.T) {
+	t.Run("Filter", func(t *testing.T) {
+		in := Filter{
+			Name:   param.Null[string](),
+			Age:    param.From(7),
+			Tags:   param.From([]string{"x", "y"}),
+			Active: param.ValOf(true),
+			Page:   3,
+		}
+		roundTrip(t, in)
+	})
+
+	testCases := []struct {
+		name  string
+		input Query
+	}{
+		{
+			name: "Opt struct value",
+			input: Query{
+				Name:    param.From("Alice"),
+				Since:   param.From(time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)),
+				IP:      param.From(net.ParseIP("10.0.0.1")),
+				Address: param.From(Address{City: param.From("Paris")}),
+				Cursor:  param.Ptr("abc"),
+				Paging:  Paging{Page: 2, Limit: 10},
+			},
+		},
+		{
+			name:  "Opt struct with a null field",
+			input: Query{Address: param.From(Address{City: param.Null[string]()})},
+		},
+		{
+			name:  "Null Opt struct",
+			input: Query{Address: param.Null[Address]()},
+		},
+		{
+			name:  "Unset Opt struct",
+			input: Query{Age: param.From(1)},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			roundTrip(t, tc.input)
+		})
+	}
+}
+
+func roundTrip[T any](t *testing.T, in T) {
+	t.Helper()
+	values, err := form.Encode(in)
+	if err != nil {
+		t.Fatalf("Encode() returned an unexpected error: %v", err)
+	}
+	var out T
+	if err := form.Decode(values, &out); err != nil {
+		t.Fatalf("Decode() returned an unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(out, in) {
+		t.Errorf("Decode(Encode()) got %+v, want %+v", out, in)
+	}
+}
+
+// TestEncodeErrors validates the error cases.
+func TestEncodeErrors(t *testing.T) {
+	if _, err := form.Encode(42); err == nil {
+		t.Error("Expected an error for a non-struct source but got nil")
+	}
+	bad := struct {
+		M param.Opt[map[int]int] `form:"m"`
+	}{M: param.From(map[int]int{1: 1})}
+	if _, err := form.Encode(bad); err == nil {
+		t.Error("Expected an error for an unsupported type but got nil")
+	}
+}
@@ -0,0 +1,24 @@
+// Package form converts between url.Values, as found in query strings and
+// application/x-www-form-urlencoded bodies, and structs of param.Opt fields.
+//
+// An absent parameter leaves its field unset, a parameter equal to the null token
+// (param.NullToken by default) sets it to null, and any other value, including an
+// empty one, is parsed into the field. Encoding follows the same rules in reverse.
+// Parameter names come from the `form` tag, then the `json` tag, then the field name.
+// Nested structs use dotted names such as "address.city", and repeated parameters
+// fill slices.
+package form
+
+import "github.com/qntx/param/internal/fielderr"
+
+// FieldError describes a parameter that could not be converted. Key is the
+// parameter name.
+type FieldError = fielderr.Error
+
+// Errors lists every parameter that could not be converted.
+type Errors = fielderr.List[pkg]
+
+// pkg prefixes the messages of Errors.
+type pkg struct{}
+
+func (pkg) Prefix() string { return "form" }
@@ -0,0 +1,49 @@
+// Package fielderr provides the per-field error types shared by the packages that
+// decode and encode structs of param.Opt fields, so that they report failures the
+// same way.
+package fielderr
+
+import "strings"
+
+// Prefix is implemented by the types naming the package in the messages of List.
+type Prefix interface {
+	Prefix() string
+}
+
+// Error describes a field that could not be converted.
+type Error struct {
+	// Key identifies the field, e.g. a parameter name, a variable name or a path
+	// such as "items[1].price".
+	Key string
+	// Err is the conversion error.
+	Err error
+}
+
+func (e Error) Error() string {
+	return e.Key + ": " + e.Err.Error()
+}
+
+func (e Error) Unwrap() error {
+	return e.Err
+}
+
+// List lists every field that could not be converted. Its message is prefixed
+// with the Prefix of P.
+type List[P Prefix] []Error
+
+func (l List[P]) Error() string {
+	var p P
+	msgs := make([]string, len(l))
+	for i, fe := range l {
+		msgs[i] = fe.Error()
+	}
+	return p.Prefix() + ": " + strings.Join(msgs, "; ")
+}
+
+func (l List[P]) Unwrap() []error {
+	errs := make([]error, len(l))
+	for i, fe := range l {
+		errs[i] = fe
+	}
+	return errs
+}
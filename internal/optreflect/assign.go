@@ -0,0 +1,104 @@
+package optreflect
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+)
+
+// Assign stores v into d, which must be settable, converting between compatible
+// types and wrapping v into Opt and pointer targets.
+func Assign(d, v reflect.Value) error {
+	switch {
+	case v.Type().AssignableTo(d.Type()):
+		d.Set(v)
+	case Is(d.Type()):
+		tmp := reflect.New(Elem(d.Type())).Elem()
+		if err := Assign(tmp, v); err != nil {
+			return err
+		}
+		Set(d, tmp)
+	case d.Kind() == reflect.Pointer:
+		tmp := reflect.New(d.Type().Elem())
+		if err := Assign(tmp.Elem(), v); err != nil {
+			return err
+		}
+		d.Set(tmp)
+	case numeric(v.Kind()) && numeric(d.Kind()) && v.Kind() != d.Kind():
+		return Number(d, v)
+	case convertible(v.Type(), d.Type()):
+		d.Set(v.Convert(d.Type()))
+	default:
+		return fmt.Errorf("cannot assign %s to %s", v.Type(), d.Type())
+	}
+	return nil
+}
+
+// convertible reports whether from converts to to without changing meaning, i.e.
+// between types of the same kind. Numbers of different kinds go through Number.
+func convertible(from, to reflect.Type) bool {
+	return from.ConvertibleTo(to) && from.Kind() == to.Kind()
+}
+
+func numeric(k reflect.Kind) bool {
+	return k >= reflect.Int && k <= reflect.Float64
+}
+
+// Number stores the number s into the numeric value v, failing instead of
+// truncating or wrapping around if s is not integral for an integer v or does
+// not fit v.
+func Number(v, s reflect.Value) error {
+	var f float64
+	switch {
+	case s.CanInt():
+		i := s.Int()
+		switch {
+		case v.CanInt() && !v.OverflowInt(i):
+			v.SetInt(i)
+			return nil
+		case v.CanUint() && i >= 0 && !v.OverflowUint(uint64(i)):
+			v.SetUint(uint64(i))
+			return nil
+		case v.CanFloat():
+			v.SetFloat(float64(i))
+			return nil
+		}
+		return fmt.Errorf("%d overflows %s", i, v.Type())
+	case s.CanUint():
+		u := s.Uint()
+		switch {
+		case v.CanInt() && u <= math.MaxInt64 && !v.OverflowInt(int64(u)):
+			v.SetInt(int64(u))
+			return nil
+		case v.CanUint() && !v.OverflowUint(u):
+			v.SetUint(u)
+			return nil
+		case v.CanFloat():
+			v.SetFloat(float64(u))
+			return nil
+		}
+		return fmt.Errorf("%d overflows %s", u, v.Type())
+	case s.CanFloat():
+		f = s.Float()
+	default:
+		return fmt.Errorf("cannot convert %s to %s", s.Type(), v.Type())
+	}
+
+	switch {
+	case v.CanFloat():
+		if v.OverflowFloat(f) {
+			return fmt.Errorf("%v overflows %s", f, v.Type())
+		}
+		v.SetFloat(f)
+		return nil
+	case f != math.Trunc(f) || math.IsInf(f, 0):
+		return fmt.Errorf("%v is not an integer", f)
+	case v.CanInt() && f >= math.MinInt64 && f < math.MaxInt64 && !v.OverflowInt(int64(f)):
+		v.SetInt(int64(f))
+		return nil
+	case v.CanUint() && f >= 0 && f < math.MaxUint64 && !v.OverflowUint(uint64(f)):
+		v.SetUint(uint64(f))
+		return nil
+	}
+	return fmt.Errorf("%v overflows %s", f, v.Type())
+}
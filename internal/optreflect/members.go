@@ -0,0 +1,110 @@
+package optreflect
+
+import (
+	"encoding"
+	"encoding/json"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+var (
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// IsObject reports whether values of typ encode as JSON objects, i.e. typ is a
+// struct without custom marshaling or a map with string keys.
+func IsObject(typ reflect.Type) bool {
+	switch typ.Kind() {
+	case reflect.Struct:
+		return !Is(typ) && !typ.Implements(jsonMarshalerType) && !typ.Implements(textMarshalerType)
+	case reflect.Map:
+		return typ.Key().Kind() == reflect.String && !Is(typ)
+	}
+	return false
+}
+
+// Member is a member present in a patch object.
+type Member struct {
+	Key string
+	// Null is true if the member is an explicit null.
+	Null bool
+	// Value holds the member value if it is not null, never an interface.
+	Value reflect.Value
+	// Source is the field or map value the member was read from, before
+	// unwrapping Opt values, pointers and interfaces.
+	Source reflect.Value
+}
+
+// Members returns the members present in the patch object p, a struct or a map
+// with string keys, the way json.Marshal would emit them: an unset Opt is absent,
+// a null Opt or nil map, slice or interface is null, nil pointers are absent and
+// plain fields are present unless tagged `omitempty` and zero. Map members are
+// sorted by key.
+func Members(p reflect.Value) []Member {
+	var out []Member
+	if p.Kind() == reflect.Map {
+		keys := p.MapKeys()
+		slices.SortFunc(keys, func(a, b reflect.Value) int {
+			return strings.Compare(a.String(), b.String())
+		})
+		for _, k := range keys {
+			if m, ok := present(k.String(), p.MapIndex(k), false); ok {
+				m.Source = p.MapIndex(k)
+				out = append(out, m)
+			}
+		}
+		return out
+	}
+
+	addr := Addressable(p)
+	for _, f := range Fields(addr.Type(), "json") {
+		omitempty := slices.Contains(f.Opts, "omitempty")
+		fv := addr.FieldByIndex(f.Index)
+		if m, ok := present(f.Key, fv, omitempty); ok {
+			m.Source = fv
+			out = append(out, m)
+		}
+	}
+	return out
+}
+
+// present resolves the state of a patch member value v.
+func present(key string, v reflect.Value, omitempty bool) (Member, bool) {
+	if v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return Member{Key: key, Null: true}, true
+		}
+		v = v.Elem()
+	}
+	switch {
+	case Is(v.Type()):
+		v = Addressable(v)
+		opt := State(v)
+		if !opt.IsSet() {
+			return Member{}, false
+		}
+		if opt.IsNull() {
+			return Member{Key: key, Null: true}, true
+		}
+		val, _ := Get(v)
+		if val.Kind() == reflect.Interface {
+			if val.IsNil() {
+				return Member{Key: key, Null: true}, true
+			}
+			val = val.Elem()
+		}
+		return Member{Key: key, Value: val}, true
+	case v.Kind() == reflect.Pointer:
+		if v.IsNil() {
+			return Member{}, false
+		}
+		return Member{Key: key, Value: v.Elem()}, true
+	case omitempty && v.IsZero():
+		return Member{}, false
+	case (v.Kind() == reflect.Map || v.Kind() == reflect.Slice) && v.IsNil():
+		return Member{Key: key, Null: true}, true
+	}
+	return Member{Key: key, Value: v}, true
+}
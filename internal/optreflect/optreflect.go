@@ -0,0 +1,139 @@
+// Package optreflect provides reflection helpers shared by the packages that walk
+// structs of param.Opt fields.
+package optreflect
+
+import (
+	"reflect"
+	"strings"
+)
+
+// Opt mirrors param.JSONOpt so this package does not have to import param.
+type Opt interface {
+	IsNull() bool
+	SetNull()
+	IsSet() bool
+	Reset()
+}
+
+var optType = reflect.TypeOf((*Opt)(nil)).Elem()
+
+// Is reports whether typ is a tri-state type, i.e. *typ implements Opt and typ has
+// a Get method returning the inner value.
+func Is(typ reflect.Type) bool {
+	if typ.Kind() == reflect.Pointer || !reflect.PointerTo(typ).Implements(optType) {
+		return false
+	}
+	m, ok := typ.MethodByName("Get")
+	return ok && m.Type.NumIn() == 1 && m.Type.NumOut() == 2
+}
+
+// Elem returns the type of the value held by the tri-state type typ.
+func Elem(typ reflect.Type) reflect.Type {
+	m, _ := typ.MethodByName("Get")
+	return m.Type.Out(0)
+}
+
+// State returns the Opt behind v, which must be addressable.
+func State(v reflect.Value) Opt {
+	return v.Addr().Interface().(Opt)
+}
+
+// Get returns the value held by v and whether it is present.
+func Get(v reflect.Value) (reflect.Value, bool) {
+	out := v.MethodByName("Get").Call(nil)
+	return out[0], out[1].Bool()
+}
+
+// Set stores x into v, which must be addressable.
+func Set(v, x reflect.Value) {
+	v.Addr().MethodByName("Set").Call([]reflect.Value{x})
+}
+
+// Name returns the name of f under the given struct tag keys, trying each in
+// order and falling back to the Go field name. The options following the name in
+// the matching tag are returned as well. ok is false if the field is skipped
+// with "-".
+func Name(f reflect.StructField, keys ...string) (name string, opts []string, ok bool) {
+	for _, key := range keys {
+		tag, found := f.Tag.Lookup(key)
+		if !found {
+			continue
+		}
+		if tag == "-" {
+			return "", nil, false
+		}
+		parts := strings.Split(tag, ",")
+		if parts[0] == "" {
+			return f.Name, parts[1:], true
+		}
+		return parts[0], parts[1:], true
+	}
+	return f.Name, nil, true
+}
+
+// Field is a struct field reachable from a top-level struct. Index is relative to
+// the top-level struct, so embedded fields can be reached with FieldByIndex.
+type Field struct {
+	reflect.StructField
+	// Key is the name of the field under the requested tag keys.
+	Key string
+	// Opts holds the tag options following the key.
+	Opts []string
+}
+
+// Fields returns the exported fields of the struct type typ, named by Name with the
+// given tag keys. Untagged embedded structs are flattened, with shallower fields
+// taking precedence over embedded ones of the same key.
+func Fields(typ reflect.Type, keys ...string) []Field {
+	var (
+		fields   []Field
+		embedded []Field
+		seen     = map[string]bool{}
+	)
+	for i := 0; i < typ.NumField(); i++ {
+		f := typ.Field(i)
+		if f.Anonymous && f.Type.Kind() == reflect.Struct && !Is(f.Type) && !tagged(f, keys) {
+			for _, ef := range Fields(f.Type, keys...) {
+				ef.Index = append([]int{i}, ef.Index...)
+				embedded = append(embedded, ef)
+			}
+			continue
+		}
+		if !f.IsExported() {
+			continue
+		}
+		key, opts, ok := Name(f, keys...)
+		if !ok {
+			continue
+		}
+		seen[key] = true
+		fields = append(fields, Field{StructField: f, Key: key, Opts: opts})
+	}
+	for _, ef := range embedded {
+		if !seen[ef.Key] {
+			seen[ef.Key] = true
+			fields = append(fields, ef)
+		}
+	}
+	return fields
+}
+
+func tagged(f reflect.StructField, keys []string) bool {
+	for _, key := range keys {
+		if _, ok := f.Tag.Lookup(key); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// Addressable returns v if it is addressable, and an addressable copy of it
+// otherwise, so pointer methods of Opt values are reachable.
+func Addressable(v reflect.Value) reflect.Value {
+	if v.CanAddr() {
+		return v
+	}
+	addr := reflect.New(v.Type()).Elem()
+	addr.Set(v)
+	return addr
+}
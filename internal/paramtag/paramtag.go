@@ -0,0 +1,55 @@
+// Package paramtag parses the `param` struct tags holding the rules checked by
+// validate and documented by openapi, so both read them the same way.
+package paramtag
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Rules are the rules of a `param` tag.
+type Rules struct {
+	// Required means the field must be set, either as null or a value.
+	Required bool
+	// NonNull means the field must not be null.
+	NonNull bool
+	// Min and Max are the bounds of a value, nil if absent.
+	Min, Max *Bound
+}
+
+// Bound is the argument of a min or max rule.
+type Bound struct {
+	Value float64
+	// Text is the bound as written in the tag, for messages.
+	Text string
+}
+
+// Parse parses a comma-separated list of rules such as "required,min=1,max=100".
+func Parse(tag string) (Rules, error) {
+	var r Rules
+	for _, rule := range strings.Split(tag, ",") {
+		name, arg, _ := strings.Cut(strings.TrimSpace(rule), "=")
+		switch name {
+		case "":
+		case "required":
+			r.Required = true
+		case "nonnull":
+			r.NonNull = true
+		case "min", "max":
+			bound, err := strconv.ParseFloat(arg, 64)
+			if err != nil {
+				return Rules{}, fmt.Errorf("invalid %s bound %q", name, arg)
+			}
+			b := &Bound{Value: bound, Text: arg}
+			if name == "min" {
+				r.Min = b
+			} else {
+				r.Max = b
+			}
+		default:
+			return Rules{}, fmt.Errorf("unknown rule %q", name)
+		}
+	}
+	return r, nil
+}
@@ -0,0 +1,157 @@
+// Package textconv converts values to and from text, the way param.Opt does.
+package textconv
+
+import (
+	"encoding"
+	"fmt"
+	"reflect"
+	"strconv"
+	"time"
+
+	"github.com/qntx/param/internal/optreflect"
+)
+
+// Format formats v as text, using its encoding.TextMarshaler implementation or
+// strconv for builtin kinds. A time.Duration is formatted like "1m30s".
+func Format(v any) (string, error) {
+	switch v := v.(type) {
+	case encoding.TextMarshaler:
+		b, err := v.MarshalText()
+		return string(b), err
+	case time.Duration:
+		return v.String(), nil
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.String:
+		return rv.String(), nil
+	case reflect.Bool:
+		return strconv.FormatBool(rv.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(rv.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(rv.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(rv.Float(), 'g', -1, rv.Type().Bits()), nil
+	}
+	return "", fmt.Errorf("cannot format %T as text", v)
+}
+
+// Parse parses s into the value pointed to by ptr, using its
+// encoding.TextUnmarshaler implementation or strconv for builtin kinds. A
+// time.Duration is parsed with time.ParseDuration.
+func Parse(s string, ptr any) error {
+	switch ptr := ptr.(type) {
+	case encoding.TextUnmarshaler:
+		return ptr.UnmarshalText([]byte(s))
+	case *time.Duration:
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return err
+		}
+		*ptr = d
+		return nil
+	}
+	rv := reflect.ValueOf(ptr).Elem()
+	switch rv.Kind() {
+	case reflect.String:
+		rv.SetString(s)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		rv.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(s, 10, rv.Type().Bits())
+		if err != nil {
+			return err
+		}
+		rv.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		u, err := strconv.ParseUint(s, 10, rv.Type().Bits())
+		if err != nil {
+			return err
+		}
+		rv.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(s, rv.Type().Bits())
+		if err != nil {
+			return err
+		}
+		rv.SetFloat(f)
+	default:
+		return fmt.Errorf("cannot parse text into %s", rv.Type())
+	}
+	return nil
+}
+
+var (
+	textMarshalerType   = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// CanFormat reports whether Format supports values of typ.
+func CanFormat(typ reflect.Type) bool {
+	return typ.Implements(textMarshalerType) || builtin(typ.Kind())
+}
+
+// CanParse reports whether Parse supports pointers to typ.
+func CanParse(typ reflect.Type) bool {
+	return reflect.PointerTo(typ).Implements(textUnmarshalerType) || builtin(typ.Kind())
+}
+
+// builtin reports whether values of kind k are converted with strconv.
+func builtin(k reflect.Kind) bool {
+	switch k {
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+// ParseValues parses vals into v, which must be settable. An Opt becomes null if
+// vals holds the single value null. Slices are filled from every value, other
+// types are parsed from the first one, and nil pointers are allocated. Empty vals
+// give an empty slice, and an error for other types; callers treating an empty
+// list as absent must check for it themselves.
+func ParseValues(v reflect.Value, vals []string, null string) error {
+	switch {
+	case optreflect.Is(v.Type()):
+		if len(vals) == 1 && vals[0] == null {
+			optreflect.State(v).SetNull()
+			return nil
+		}
+		tmp := reflect.New(optreflect.Elem(v.Type())).Elem()
+		if err := ParseValues(tmp, vals, null); err != nil {
+			return err
+		}
+		optreflect.Set(v, tmp)
+		return nil
+	case CanParse(v.Type()):
+		if len(vals) == 0 {
+			return fmt.Errorf("missing value for %s", v.Type())
+		}
+		return Parse(vals[0], v.Addr().Interface())
+	case v.Kind() == reflect.Pointer:
+		tmp := reflect.New(v.Type().Elem())
+		if err := ParseValues(tmp.Elem(), vals, null); err != nil {
+			return err
+		}
+		v.Set(tmp)
+		return nil
+	case v.Kind() == reflect.Slice:
+		s := reflect.MakeSlice(v.Type(), len(vals), len(vals))
+		for i, val := range vals {
+			if err := ParseValues(s.Index(i), []string{val}, null); err != nil {
+				return err
+			}
+		}
+		v.Set(s)
+		return nil
+	}
+	return fmt.Errorf("cannot decode into %s", v.Type())
+}
@@ -0,0 +1,71 @@
+// Package jsonpatch generates RFC 6902 JSON Patch operations from a struct of
+// param.Opt fields.
+package jsonpatch
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/qntx/param/internal/optreflect"
+)
+
+// Operation is a single RFC 6902 JSON Patch operation.
+type Operation struct {
+	Op    string `json:"op"`
+	Path  string `json:"path"`
+	Value any    `json:"value,omitempty"`
+}
+
+// Operation kinds. Generate only emits OpAdd and OpRemove.
+const (
+	OpAdd     = "add"
+	OpRemove  = "remove"
+	OpReplace = "replace"
+)
+
+// Generate returns the JSON Patch operations equivalent to patch, a struct, a map
+// with string keys, or a pointer to either.
+//
+// A null member becomes a "remove", a value becomes an "add", and unset members
+// produce nothing. An "add" to an object member creates or replaces it, whereas a
+// "replace" fails when the member is missing on the target (RFC 6902 §4.3), as
+// it is for fields that were previously omitted or removed. A "remove" of a
+// missing member fails too (§4.2). Nested objects are walked recursively, so their
+// operations assume the parent object exists on the target. Paths are built from
+// `json` tags and escaped as JSON Pointers.
+func Generate(patch any) ([]Operation, error) {
+	v := reflect.ValueOf(patch)
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return nil, nil
+		}
+		v = v.Elem()
+	}
+	if !v.IsValid() || !optreflect.IsObject(v.Type()) {
+		return nil, fmt.Errorf("jsonpatch: patch must be an object, got %T", patch)
+	}
+	return generate(nil, "", v), nil
+}
+
+func generate(ops []Operation, prefix string, p reflect.Value) []Operation {
+	for _, m := range optreflect.Members(p) {
+		path := prefix + "/" + Escape(m.Key)
+		switch {
+		case m.Null:
+			ops = append(ops, Operation{Op: OpRemove, Path: path})
+		case optreflect.IsObject(m.Value.Type()):
+			ops = generate(ops, path, m.Value)
+		default:
+			ops = append(ops, Operation{Op: OpAdd, Path: path, Value: m.Value.Interface()})
+		}
+	}
+	return ops
+}
+
+var escaper = strings.NewReplacer("~", "~0", "/", "~1")
+
+// Escape escapes a single JSON Pointer reference token as described in RFC 6901.
+func Escape(token string) string {
+	return escaper.Replace(token)
+}
@@ -0,0 +1,120 @@
+package jsonpatch_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/qntx/param"
+	"github.com/qntx/param/jsonpatch"
+)
+
+type AddressPatch struct {
+	City   param.Opt[string] `json:"city,omitempty"`
+	Street param.Opt[string] `json:"street,omitempty"`
+}
+
+type UserPatch struct {
+	Name    param.Opt[string]         `json:"name,omitempty"`
+	Age     param.Opt[int]            `json:"age,omitempty"`
+	Path    param.Opt[string]         `json:"a/b~c,omitempty"`
+	Address param.Opt[AddressPatch]   `json:"address,omitempty"`
+	Labels  param.Opt[map[string]any] `json:"labels,omitempty"`
+	Tags    param.Opt[[]string]       `json:"tags,omitempty"`
+}
+
+// TestGenerate validates the operations generated for each field state.
+func TestGenerate(t *testing.T) {
+	testCases := []struct {
+		name  string
+		patch UserPatch
+		want  []jsonpatch.Operation
+	}{
+		{
+			name:  "Unset fields produce nothing",
+			patch: UserPatch{},
+			want:  nil,
+		},
+		{
+			name:  "Values add and nulls remove",
+			patch: UserPatch{Name: param.From("Alice"), Age: param.Null[int](), Tags: param.From([]string{"a"})},
+			want: []jsonpatch.Operation{
+				{Op: jsonpatch.OpAdd, Path: "/name", Value: "Alice"},
+				{Op: jsonpatch.OpRemove, Path: "/age"},
+				{Op: jsonpatch.OpAdd, Path: "/tags", Value: []string{"a"}},
+			},
+		},
+		{
+			name:  "Paths are escaped",
+			patch: UserPatch{Path: param.From("x")},
+			want: []jsonpatch.Operation{
+				{Op: jsonpatch.OpAdd, Path: "/a~1b~0c", Value: "x"},
+			},
+		},
+		{
+			name: "Nested structs and maps are walked",
+			patch: UserPatch{
+				Address: param.From(AddressPatch{City: param.From("Lyon"), Street: param.Null[string]()}),
+				Labels:  param.From(map[string]any{"team": "core", "old": nil, "x/y": 1}),
+			},
+			want: []jsonpatch.Operation{
+				{Op: jsonpatch.OpAdd, Path: "/address/city", Value: "Lyon"},
+				{Op: jsonpatch.OpRemove, Path: "/address/street"},
+				{Op: jsonpatch.OpRemove, Path: "/labels/old"},
+				{Op: jsonpatch.OpAdd, Path: "/labels/team", Value: "core"},
+				{Op: jsonpatch.OpAdd, Path: "/labels/x~1y", Value: 1},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := jsonpatch.Generate(&tc.patch)
+			if err != nil {
+				t.Fatalf("Generate() returned an unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("Generate() got %#v, want %#v", got, tc.want)
+			}
+		})
+	}
+}
+
+// TestGenerateJSON validates the wire format of the operations.
+func TestGenerateJSON(t *testing.T) {
+	ops, err := jsonpatch.Generate(UserPatch{Name: param.From("Alice"), Age: param.Null[int]()})
+	if err != nil {
+		t.Fatalf("Generate() returned an unexpected error: %v", err)
+	}
+	got, err := json.Marshal(ops)
+	if err != nil {
+		t.Fatalf("json.Marshal() returned an unexpected error: %v", err)
+	}
+	want := `[{"op":"add","path":"/name","value":"Alice"},{"op":"remove","path":"/age"}]`
+	if string(got) != want {
+		t.Errorf("json.Marshal() got %s, want %s", got, want)
+	}
+}
+
+// TestGenerateErrors validates that non-object patches are rejected.
+func TestGenerateErrors(t *testing.T) {
+	if _, err := jsonpatch.Generate("not an object"); err == nil {
+		t.Error("Expected an error for a non-object patch but got nil")
+	}
+}
+
+// TestEscape validates JSON Pointer escaping.
+func TestEscape(t *testing.T) {
+	testCases := map[string]string{
+		"plain": "plain",
+		"a/b":   "a~1b",
+		"m~n":   "m~0n",
+		"~/":    "~0~1",
+		"~1":    "~01",
+	}
+	for in, want := range testCases {
+		if got := jsonpatch.Escape(in); got != want {
+			t.Errorf("Escape(%q) got %q, want %q", in, got, want)
+		}
+	}
+}
@@ -0,0 +1,235 @@
+// Package mapdecode decodes generic maps, such as GraphQL arguments or decoded
+// message payloads, into structs of param.Opt fields without a JSON round trip.
+//
+// A missing key leaves its field unset and a nil value sets it to null. Values are
+// converted the way encoding/json would decode their JSON form: numbers are
+// converted between numeric types as long as they fit, nested maps fill nested
+// structs and maps, slices fill slices and arrays, and strings are parsed by
+// types implementing encoding.TextUnmarshaler such as time.Time. json.Number
+// values are accepted wherever numbers are.
+package mapdecode
+
+import (
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+
+	"github.com/qntx/param/internal/fielderr"
+	"github.com/qntx/param/internal/optreflect"
+)
+
+var (
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+	numberType          = reflect.TypeOf(json.Number(""))
+)
+
+// Decoder decodes maps into structs.
+type Decoder struct {
+	// Tag is the struct tag holding keys, falling back to `json`.
+	Tag string
+	// DisallowUnknownFields reports keys without a matching struct field as errors.
+	DisallowUnknownFields bool
+}
+
+// NewDecoder returns a Decoder reading the `json` tag.
+func NewDecoder() *Decoder {
+	return &Decoder{Tag: "json"}
+}
+
+// Decode is shorthand for NewDecoder().Decode(src, dst).
+func Decode(src map[string]any, dst any) error {
+	return NewDecoder().Decode(src, dst)
+}
+
+// Decode decodes src into the struct pointed to by dst. Conversion errors do not
+// stop decoding; they are all returned as Errors.
+func (d *Decoder) Decode(src map[string]any, dst any) error {
+	v := reflect.ValueOf(dst)
+	if v.Kind() != reflect.Pointer || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("mapdecode: dst must be a non-nil pointer to a struct, got %T", dst)
+	}
+	var errs Errors
+	d.decodeStruct(&errs, v.Elem(), reflect.ValueOf(src), "")
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// decode stores the source value s into the settable value v.
+func (d *Decoder) decode(errs *Errors, v, s reflect.Value, path string) {
+	fail := func(err error) {
+		*errs = append(*errs, FieldError{Key: path, Err: err})
+	}
+	if s.Kind() == reflect.Interface {
+		s = s.Elem()
+	}
+	if !s.IsValid() {
+		switch {
+		case optreflect.Is(v.Type()):
+			optreflect.State(v).SetNull()
+		case v.Kind() == reflect.Pointer, v.Kind() == reflect.Map, v.Kind() == reflect.Slice, v.Kind() == reflect.Interface:
+			v.Set(reflect.Zero(v.Type()))
+		}
+		return
+	}
+
+	switch {
+	case optreflect.Is(v.Type()):
+		tmp := reflect.New(optreflect.Elem(v.Type())).Elem()
+		n := len(*errs)
+		d.decode(errs, tmp, s, path)
+		if len(*errs) == n {
+			optreflect.Set(v, tmp)
+		}
+		return
+	case s.Type().AssignableTo(v.Type()):
+		v.Set(s)
+		return
+	case v.Kind() == reflect.Pointer:
+		tmp := reflect.New(v.Type().Elem())
+		n := len(*errs)
+		d.decode(errs, tmp.Elem(), s, path)
+		if len(*errs) == n {
+			v.Set(tmp)
+		}
+		return
+	case s.Kind() == reflect.String && reflect.PointerTo(v.Type()).Implements(textUnmarshalerType):
+		if err := v.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(s.String())); err != nil {
+			fail(err)
+		}
+		return
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		if !stringMap(s) {
+			fail(mismatch(s, v.Type()))
+			return
+		}
+		d.decodeStruct(errs, v, s, path)
+	case reflect.Map:
+		if !stringMap(s) || v.Type().Key().Kind() != reflect.String {
+			fail(mismatch(s, v.Type()))
+			return
+		}
+		m := reflect.MakeMapWithSize(v.Type(), s.Len())
+		iter := s.MapRange()
+		for iter.Next() {
+			elem := reflect.New(v.Type().Elem()).Elem()
+			d.decode(errs, elem, iter.Value(), join(path, iter.Key().String()))
+			m.SetMapIndex(iter.Key().Convert(v.Type().Key()), elem)
+		}
+		v.Set(m)
+	case reflect.Slice, reflect.Array:
+		if s.Kind() != reflect.Slice && s.Kind() != reflect.Array {
+			fail(mismatch(s, v.Type()))
+			return
+		}
+		target := v
+		if v.Kind() == reflect.Slice {
+			target = reflect.MakeSlice(v.Type(), s.Len(), s.Len())
+		} else if s.Len() != v.Len() {
+			fail(fmt.Errorf("cannot decode %d elements into %s", s.Len(), v.Type()))
+			return
+		}
+		for i := 0; i < s.Len(); i++ {
+			d.decode(errs, target.Index(i), s.Index(i), path+"["+strconv.Itoa(i)+"]")
+		}
+		v.Set(target)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		if err := number(v, s); err != nil {
+			fail(err)
+		}
+	case reflect.String, reflect.Bool:
+		if s.Kind() != v.Kind() || s.Type() == numberType {
+			fail(mismatch(s, v.Type()))
+			return
+		}
+		v.Set(s.Convert(v.Type()))
+	default:
+		fail(mismatch(s, v.Type()))
+	}
+}
+
+// decodeStruct decodes the members of the string-keyed map s into the struct v.
+func (d *Decoder) decodeStruct(errs *Errors, v, s reflect.Value, path string) {
+	known := map[string]bool{}
+	for _, f := range optreflect.Fields(v.Type(), d.Tag, "json") {
+		known[f.Key] = true
+		val := s.MapIndex(reflect.ValueOf(f.Key).Convert(s.Type().Key()))
+		if !val.IsValid() {
+			continue
+		}
+		d.decode(errs, v.FieldByIndex(f.Index), val, join(path, f.Key))
+	}
+	if !d.DisallowUnknownFields {
+		return
+	}
+	var unknown []string
+	for _, k := range s.MapKeys() {
+		if !known[k.String()] {
+			unknown = append(unknown, k.String())
+		}
+	}
+	slices.Sort(unknown)
+	for _, key := range unknown {
+		*errs = append(*errs, FieldError{Key: join(path, key), Err: errors.New("unknown field")})
+	}
+}
+
+// number stores the number s into the numeric value v, failing if it does not fit.
+func number(v, s reflect.Value) error {
+	switch {
+	case s.Type() == numberType:
+		// Parse integers directly so large ones keep their precision.
+		if i, err := strconv.ParseInt(s.String(), 10, 64); err == nil {
+			return optreflect.Number(v, reflect.ValueOf(i))
+		}
+		if u, err := strconv.ParseUint(s.String(), 10, 64); err == nil {
+			return optreflect.Number(v, reflect.ValueOf(u))
+		}
+		f, err := strconv.ParseFloat(s.String(), 64)
+		if err != nil {
+			return err
+		}
+		return optreflect.Number(v, reflect.ValueOf(f))
+	case s.CanInt(), s.CanUint(), s.CanFloat():
+		return optreflect.Number(v, s)
+	}
+	return mismatch(s, v.Type())
+}
+
+// stringMap reports whether s is a map with string keys.
+func stringMap(s reflect.Value) bool {
+	return s.Kind() == reflect.Map && s.Type().Key().Kind() == reflect.String
+}
+
+func mismatch(s reflect.Value, typ reflect.Type) error {
+	return fmt.Errorf("cannot decode %s into %s", s.Type(), typ)
+}
+
+func join(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+// FieldError describes a value that could not be decoded. Key is the path of the
+// value, built from keys and indexes, e.g. "items[1].price".
+type FieldError = fielderr.Error
+
+// Errors lists every value that could not be decoded.
+type Errors = fielderr.List[pkg]
+
+// pkg prefixes the messages of Errors.
+type pkg struct{}
+
+func (pkg) Prefix() string { return "mapdecode" }
@@ -0,0 +1,148 @@
+package mapdecode_test
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/qntx/param"
+	"github.com/qntx/param/mapdecode"
+)
+
+type Item struct {
+	SKU   string           `json:"sku"`
+	Price param.Opt[int64] `json:"price"`
+}
+
+type Address struct {
+	City param.Opt[string] `json:"city"`
+}
+
+type OrderPatch struct {
+	Note     param.Opt[string]         `json:"note"`
+	Discount param.Opt[float64]        `json:"discount"`
+	Quantity param.Opt[uint8]          `json:"quantity"`
+	Due      param.Opt[time.Time]      `json:"due"`
+	Tags     param.Opt[[]string]       `json:"tags"`
+	Items    []Item                    `json:"items"`
+	Address  param.Opt[Address]        `json:"address"`
+	Billing  *Address                  `json:"billing"`
+	Meta     map[string]param.Opt[int] `json:"meta"`
+	Extra    any                       `json:"extra"`
+	Rush     param.Val[bool]           `json:"rush"`
+	Ignored  param.Opt[string]         `json:"-"`
+}
+
+// TestDecode validates the tri-state decoding and conversions.
+func TestDecode(t *testing.T) {
+	due := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	src := map[string]any{
+		"note":     nil,
+		"discount": 5,
+		"quantity": json.Number("3"),
+		"due":      "2024-05-01T12:00:00Z",
+		"tags":     []any{"a", "b"},
+		"items":    []any{map[string]any{"sku": "x", "price": 9.0}, map[string]any{"sku": "y", "price": nil}},
+		"address":  map[string]any{"city": "Paris"},
+		"billing":  map[string]any{},
+		"meta":     map[string]any{"a": int32(1), "b": nil},
+		"extra":    []any{1, "two"},
+		"rush":     true,
+		"Ignored":  "x",
+	}
+
+	var got OrderPatch
+	if err := mapdecode.Decode(src, &got); err != nil {
+		t.Fatalf("Decode() returned an unexpected error: %v", err)
+	}
+
+	want := OrderPatch{
+		Note:     param.Null[string](),
+		Discount: param.From(5.0),
+		Quantity: param.From(uint8(3)),
+		Due:      param.From(due),
+		Tags:     param.From([]string{"a", "b"}),
+		Items:    []Item{{SKU: "x", Price: param.From(int64(9))}, {SKU: "y", Price: param.Null[int64]()}},
+		Address:  param.From(Address{City: param.From("Paris")}),
+		Billing:  &Address{},
+		Meta:     map[string]param.Opt[int]{"a": param.From(1), "b": param.Null[int]()},
+		Extra:    []any{1, "two"},
+		Rush:     param.ValOf(true),
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Decode() got %+v, want %+v", got, want)
+	}
+}
+
+// TestDecodeMissing validates that missing keys leave fields unset.
+func TestDecodeMissing(t *testing.T) {
+	got := OrderPatch{Items: []Item{{SKU: "kept"}}}
+	if err := mapdecode.Decode(map[string]any{}, &got); err != nil {
+		t.Fatalf("Decode() returned an unexpected error: %v", err)
+	}
+	if got.Note.IsSet() || got.Discount.IsSet() || got.Address.IsSet() || got.Rush.IsSet() {
+		t.Errorf("Decode() got %+v, want every Opt unset", got)
+	}
+	if len(got.Items) != 1 {
+		t.Error("Decode() should leave fields of missing keys untouched")
+	}
+}
+
+// TestDecodeErrors validates that every error is reported with its path.
+func TestDecodeErrors(t *testing.T) {
+	src := map[string]any{
+		"note":     42,
+		"discount": "5",
+		"quantity": 300,
+		"items":    []any{map[string]any{"sku": "x"}, map[string]any{"price": 1.5}},
+		"address":  "Paris",
+		"due":      "tomorrow",
+		"rush":     true,
+	}
+
+	var got OrderPatch
+	err := mapdecode.Decode(src, &got)
+
+	var errs mapdecode.Errors
+	if !errors.As(err, &errs) {
+		t.Fatalf("Decode() error got %v, want mapdecode.Errors", err)
+	}
+	var paths []string
+	for _, fe := range errs {
+		paths = append(paths, fe.Key)
+	}
+	want := []string{"note", "discount", "quantity", "due", "items[1].price", "address"}
+	if !reflect.DeepEqual(paths, want) {
+		t.Errorf("Decode() error paths got %q, want %q", paths, want)
+	}
+	if got.Quantity.IsSet() || got.Note.IsSet() {
+		t.Error("Decode() should leave fields failing to decode unset")
+	}
+	if v, ok := got.Rush.Get(); !ok || !v {
+		t.Error("Decode() should keep decoding after an error")
+	}
+	if !strings.Contains(err.Error(), "items[1].price: 1.5 is not an integer") {
+		t.Errorf("Decode() error %q should explain the items[1].price failure", err)
+	}
+
+	if err := mapdecode.Decode(src, got); err == nil {
+		t.Error("Expected an error for a non-pointer destination but got nil")
+	}
+}
+
+// TestDisallowUnknownFields validates the reporting of unknown keys.
+func TestDisallowUnknownFields(t *testing.T) {
+	d := mapdecode.NewDecoder()
+	d.DisallowUnknownFields = true
+
+	var got OrderPatch
+	err := d.Decode(map[string]any{"note": "x", "nope": 1, "address": map[string]any{"town": "Paris"}}, &got)
+
+	var errs mapdecode.Errors
+	if !errors.As(err, &errs) || len(errs) != 2 || errs[0].Key != "address.town" || errs[1].Key != "nope" {
+		t.Errorf("Decode() error got %v, want unknown address.town and nope", err)
+	}
+}
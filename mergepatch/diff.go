@@ -0,0 +1,124 @@
+package mergepatch
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/qntx/param/internal/optreflect"
+)
+
+// Diff returns the patch P that turns old into new, comparing fields with
+// reflect.DeepEqual. See DiffFunc.
+func Diff[P, T any](old, new T) (P, error) {
+	return DiffFunc[P](old, new, reflect.DeepEqual)
+}
+
+// DiffFunc returns the patch P that turns old into new, comparing fields with
+// equal. T is a struct (or pointer to one) and P its Opt-based patch counterpart,
+// with fields matched by their `json` tag.
+//
+// Each Opt field of P is left unset if the matching field is equal in old and new,
+// set to null if it changed to a nil pointer, map, slice or interface (or to a null
+// or unset Opt), and set to the new value otherwise. When the patch field holds a
+// different struct type than T, such as a nested patch struct, it is diffed
+// recursively. Applying the result to old with Apply yields new.
+func DiffFunc[P, T any](old, new T, equal func(a, b any) bool) (P, error) {
+	var patch P
+	pv := reflect.ValueOf(&patch).Elem()
+	if pv.Kind() != reflect.Struct {
+		return patch, fmt.Errorf("mergepatch: patch must be a struct, got %s", pv.Type())
+	}
+	ov, oldNull := resolve(reflect.ValueOf(&old).Elem())
+	nv, newNull := resolve(reflect.ValueOf(&new).Elem())
+	if oldNull {
+		ov = reflect.Zero(nv.Type())
+	}
+	if newNull {
+		nv = reflect.Zero(ov.Type())
+	}
+	if ov.Kind() != reflect.Struct {
+		return patch, fmt.Errorf("mergepatch: cannot diff %T", old)
+	}
+	err := diff(pv, ov, nv, equal, "")
+	return patch, err
+}
+
+// diff fills the patch struct p with the differences between the structs old and
+// new, which share the same type.
+func diff(p, old, new reflect.Value, equal func(a, b any) bool, path string) error {
+	fields := map[string][]int{}
+	for _, f := range optreflect.Fields(old.Type(), "json") {
+		fields[f.Key] = f.Index
+	}
+
+	for _, pf := range optreflect.Fields(p.Type(), "json") {
+		index, ok := fields[pf.Key]
+		if !ok || !optreflect.Is(pf.Type) {
+			continue
+		}
+		of, nf := field(old, index), field(new, index)
+		if equal(of.Interface(), nf.Interface()) {
+			continue
+		}
+
+		sub := join(path, pf.Key)
+		slot := p.FieldByIndex(pf.Index)
+		nv, null := resolve(nf)
+		if null {
+			optreflect.State(slot).SetNull()
+			continue
+		}
+
+		elem := optreflect.Elem(slot.Type())
+		if nv.Kind() == reflect.Struct && elem.Kind() == reflect.Struct && elem != nv.Type() && optreflect.IsObject(elem) {
+			ov, oldNull := resolve(of)
+			if oldNull {
+				ov = reflect.Zero(nv.Type())
+			}
+			tmp := reflect.New(elem).Elem()
+			if err := diff(tmp, ov, nv, equal, sub); err != nil {
+				return err
+			}
+			optreflect.Set(slot, tmp)
+			continue
+		}
+
+		if err := optreflect.Assign(slot, nv); err != nil {
+			return fmt.Errorf("mergepatch: field %q: %w", sub, err)
+		}
+	}
+	return nil
+}
+
+// field returns the field of v at index, or a zero value if it sits behind a nil
+// embedded pointer.
+func field(v reflect.Value, index []int) reflect.Value {
+	f, err := v.FieldByIndexErr(index)
+	if err != nil {
+		return reflect.Zero(v.Type().FieldByIndex(index).Type)
+	}
+	return f
+}
+
+// resolve dereferences pointers, interfaces and Opts, reporting whether v is null.
+func resolve(v reflect.Value) (reflect.Value, bool) {
+	for {
+		switch {
+		case optreflect.Is(v.Type()):
+			val, ok := optreflect.Get(v)
+			if !ok {
+				return v, true
+			}
+			v = val
+		case v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface:
+			if v.IsNil() {
+				return v, true
+			}
+			v = v.Elem()
+		case v.Kind() == reflect.Map || v.Kind() == reflect.Slice:
+			return v, v.IsNil()
+		default:
+			return v, false
+		}
+	}
+}
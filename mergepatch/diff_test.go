@@ -0,0 +1,127 @@
+package mergepatch_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/qntx/param"
+	"github.com/qntx/param/mergepatch"
+)
+
+// TestDiff validates the patch computed between two versions of a struct.
+func TestDiff(t *testing.T) {
+	testCases := []struct {
+		name string
+		edit func(u *User)
+		want UserPatch
+	}{
+		{
+			name: "No changes",
+			edit: func(u *User) {},
+			want: UserPatch{},
+		},
+		{
+			name: "Changed values are set",
+			edit: func(u *User) {
+				u.Name, u.Age, u.Nick = "Bob", param.Ptr(41), param.From("bobby")
+			},
+			want: UserPatch{Name: param.From("Bob"), Age: param.From(41), Nick: param.From("bobby")},
+		},
+		{
+			name: "Values going to nil are null",
+			edit: func(u *User) {
+				u.Age, u.Nick, u.Prefs = nil, param.Null[string](), nil
+			},
+			want: UserPatch{Age: param.Null[int](), Nick: param.Null[string](), Prefs: param.Null[map[string]any]()},
+		},
+		{
+			name: "Nested structs are diffed recursively",
+			edit: func(u *User) {
+				u.Address.City = "Lyon"
+				u.Billing = &Address{Street: "Main St"}
+			},
+			want: UserPatch{
+				Address: param.From(AddressPatch{City: param.From("Lyon")}),
+				Billing: param.From(AddressPatch{Street: param.From("Main St")}),
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			old, updated := newUser(), newUser()
+			tc.edit(&updated)
+
+			got, err := mergepatch.Diff[UserPatch](old, updated)
+			if err != nil {
+				t.Fatalf("Diff() returned an unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("Diff() got %+v, want %+v", got, tc.want)
+			}
+
+			// Applying the diff to the old version must yield the new one.
+			if err := mergepatch.Apply(&old, got); err != nil {
+				t.Fatalf("Apply() returned an unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(old, updated) {
+				t.Errorf("Apply(old, Diff(old, new)) got %+v, want %+v", old, updated)
+			}
+		})
+	}
+}
+
+// TestDiffFunc validates diffing with a custom equality.
+func TestDiffFunc(t *testing.T) {
+	old, updated := newUser(), newUser()
+	updated.Name = "ALICE"
+
+	foldEqual := func(a, b any) bool {
+		if sa, ok := a.(string); ok {
+			sb, _ := b.(string)
+			return strings.EqualFold(sa, sb)
+		}
+		return reflect.DeepEqual(a, b)
+	}
+
+	got, err := mergepatch.DiffFunc[UserPatch](old, updated, foldEqual)
+	if err != nil {
+		t.Fatalf("DiffFunc() returned an unexpected error: %v", err)
+	}
+	if got.Name.IsSet() {
+		t.Errorf("DiffFunc() Name got %v, want unset", got.Name)
+	}
+}
+
+// TestDiffErrors validates the error cases.
+func TestDiffErrors(t *testing.T) {
+	t.Run("Non-struct patch", func(t *testing.T) {
+		if _, err := mergepatch.Diff[map[string]any](newUser(), newUser()); err == nil {
+			t.Error("Expected an error for a non-struct patch but got nil")
+		}
+	})
+
+	t.Run("Type mismatch", func(t *testing.T) {
+		type BadPatch struct {
+			Name param.Opt[int] `json:"name"`
+		}
+		old, updated := newUser(), newUser()
+		updated.Name = "Bob"
+		if _, err := mergepatch.Diff[BadPatch](old, updated); err == nil {
+			t.Error("Expected a type mismatch error but got nil")
+		}
+	})
+
+	t.Run("Lossy numeric conversion", func(t *testing.T) {
+		type Stored struct {
+			Score float64 `json:"score"`
+		}
+		type ScorePatch struct {
+			Score param.Opt[int] `json:"score"`
+		}
+		if _, err := mergepatch.Diff[ScorePatch](Stored{Score: 1}, Stored{Score: 1.5}); err == nil {
+			t.Error("Expected a conversion error but got nil")
+		}
+	})
+}
@@ -0,0 +1,154 @@
+// Package mergepatch applies RFC 7386 JSON Merge Patch semantics to Go values,
+// using param.Opt fields to tell absent members apart from null ones.
+package mergepatch
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/qntx/param/internal/optreflect"
+)
+
+// Apply merges patch into the value pointed to by dst, following RFC 7386.
+//
+// patch is a struct, a map with string keys, or a pointer to either. Each member is
+// treated the way json.Marshal would emit it: an unset Opt is absent and left
+// untouched, a null Opt (or nil map entry) deletes the target member, and a value
+// replaces it. Plain fields are always present unless tagged `omitempty` and zero,
+// and nil pointers are absent.
+//
+// Objects (structs and string-keyed maps) are merged recursively, allocating nil
+// pointers and maps on the way. Deleting a struct field sets it to null if it is
+// an Opt and to its zero value otherwise. Members are matched by their `json` tag.
+func Apply(dst, patch any) error {
+	dv := reflect.ValueOf(dst)
+	if dv.Kind() != reflect.Pointer || dv.IsNil() {
+		return fmt.Errorf("mergepatch: dst must be a non-nil pointer, got %T", dst)
+	}
+	pv := reflect.ValueOf(patch)
+	for pv.Kind() == reflect.Pointer {
+		if pv.IsNil() {
+			return nil
+		}
+		pv = pv.Elem()
+	}
+	if !optreflect.IsObject(pv.Type()) {
+		return fmt.Errorf("mergepatch: patch must be an object, got %T", patch)
+	}
+	return mergeInto(dv.Elem(), pv, "")
+}
+
+// mergeInto merges the patch object p into the target d, which must be settable.
+func mergeInto(d, p reflect.Value, path string) error {
+	switch {
+	case optreflect.Is(d.Type()):
+		tmp := reflect.New(optreflect.Elem(d.Type())).Elem()
+		if cur, ok := optreflect.Get(d); ok {
+			tmp.Set(cur)
+		}
+		if err := mergeInto(tmp, p, path); err != nil {
+			return err
+		}
+		optreflect.Set(d, tmp)
+		return nil
+	case d.Kind() == reflect.Pointer:
+		if d.IsNil() {
+			d.Set(reflect.New(d.Type().Elem()))
+		}
+		return mergeInto(d.Elem(), p, path)
+	case d.Kind() == reflect.Interface:
+		// A non-object target is replaced by an empty object before merging.
+		m, ok := d.Interface().(map[string]any)
+		if !ok {
+			m = map[string]any{}
+		}
+		mv := reflect.ValueOf(m)
+		if err := mergeMembers(mv, p, path); err != nil {
+			return err
+		}
+		d.Set(mv)
+		return nil
+	case d.Kind() == reflect.Map && optreflect.IsObject(d.Type()):
+		if d.IsNil() {
+			d.Set(reflect.MakeMap(d.Type()))
+		}
+		return mergeMembers(d, p, path)
+	case d.Kind() == reflect.Struct && optreflect.IsObject(d.Type()):
+		return mergeMembers(d, p, path)
+	}
+	return fmt.Errorf("mergepatch: field %q: cannot merge object into %s", path, d.Type())
+}
+
+// mergeMembers applies every member of the patch object p to the object d.
+func mergeMembers(d, p reflect.Value, path string) error {
+	var fields map[string][]int
+	if d.Kind() == reflect.Struct {
+		fields = map[string][]int{}
+		for _, f := range optreflect.Fields(d.Type(), "json") {
+			fields[f.Key] = f.Index
+		}
+	}
+
+	for _, m := range optreflect.Members(p) {
+		sub := join(path, m.Key)
+
+		if d.Kind() == reflect.Map {
+			key := reflect.ValueOf(m.Key).Convert(d.Type().Key())
+			if m.Null {
+				d.SetMapIndex(key, reflect.Value{})
+				continue
+			}
+			slot := reflect.New(d.Type().Elem()).Elem()
+			if cur := d.MapIndex(key); cur.IsValid() {
+				slot.Set(cur)
+			}
+			if err := assign(slot, m.Value, sub); err != nil {
+				return err
+			}
+			d.SetMapIndex(key, slot)
+			continue
+		}
+
+		index, ok := fields[m.Key]
+		if !ok {
+			return fmt.Errorf("mergepatch: unknown field %q", sub)
+		}
+		slot, err := d.FieldByIndexErr(index)
+		if err != nil {
+			return fmt.Errorf("mergepatch: field %q: %w", sub, err)
+		}
+		if m.Null {
+			if optreflect.Is(slot.Type()) {
+				optreflect.State(slot).SetNull()
+			} else {
+				slot.Set(reflect.Zero(slot.Type()))
+			}
+			continue
+		}
+		if err := assign(slot, m.Value, sub); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// assign replaces the target d with the patch value v, merging objects.
+func assign(d, v reflect.Value, path string) error {
+	if v.Kind() == reflect.Interface {
+		v = v.Elem()
+	}
+	if optreflect.IsObject(v.Type()) {
+		return mergeInto(d, v, path)
+	}
+	if err := optreflect.Assign(d, v); err != nil {
+		return fmt.Errorf("mergepatch: field %q: %w", path, err)
+	}
+	return nil
+}
+
+func join(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
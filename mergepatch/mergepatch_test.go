@@ -0,0 +1,224 @@
+package mergepatch_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/qntx/param"
+	"github.com/qntx/param/mergepatch"
+)
+
+type Address struct {
+	City   string `json:"city"`
+	Street string `json:"street"`
+}
+
+type User struct {
+	Name     string            `json:"name"`
+	Age      *int              `json:"age"`
+	Nick     param.Opt[string] `json:"nick"`
+	Address  Address           `json:"address"`
+	Billing  *Address          `json:"billing"`
+	Prefs    map[string]any    `json:"prefs"`
+	JoinedAt time.Time         `json:"joined_at"`
+}
+
+type AddressPatch struct {
+	City   param.Opt[string] `json:"city,omitempty"`
+	Street param.Opt[string] `json:"street,omitempty"`
+}
+
+type UserPatch struct {
+	Name     param.Opt[string]         `json:"name,omitempty"`
+	Age      param.Opt[int]            `json:"age,omitempty"`
+	Nick     param.Opt[string]         `json:"nick,omitempty"`
+	Address  param.Opt[AddressPatch]   `json:"address,omitempty"`
+	Billing  param.Opt[AddressPatch]   `json:"billing,omitempty"`
+	Prefs    param.Opt[map[string]any] `json:"prefs,omitempty"`
+	JoinedAt param.Opt[time.Time]      `json:"joined_at,omitempty"`
+}
+
+func newUser() User {
+	return User{
+		Name:    "Alice",
+		Age:     param.Ptr(30),
+		Nick:    param.From("al"),
+		Address: Address{City: "Paris", Street: "Rue de Rivoli"},
+		Prefs:   map[string]any{"theme": "dark", "lang": "fr"},
+	}
+}
+
+// TestApply validates merging a patch struct into a domain struct.
+func TestApply(t *testing.T) {
+	joined := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name  string
+		patch UserPatch
+		want  func(u *User)
+	}{
+		{
+			name:  "Unset fields are untouched",
+			patch: UserPatch{},
+			want:  func(u *User) {},
+		},
+		{
+			name:  "Values replace plain, pointer and Opt fields",
+			patch: UserPatch{Name: param.From("Bob"), Age: param.From(41), Nick: param.From("bobby"), JoinedAt: param.From(joined)},
+			want: func(u *User) {
+				u.Name, u.Age, u.Nick, u.JoinedAt = "Bob", param.Ptr(41), param.From("bobby"), joined
+			},
+		},
+		{
+			name:  "Null clears plain, pointer and Opt fields",
+			patch: UserPatch{Name: param.Null[string](), Age: param.Null[int](), Nick: param.Null[string]()},
+			want: func(u *User) {
+				u.Name, u.Age, u.Nick = "", nil, param.Null[string]()
+			},
+		},
+		{
+			name:  "Nested objects are merged recursively",
+			patch: UserPatch{Address: param.From(AddressPatch{City: param.From("Lyon")})},
+			want: func(u *User) {
+				u.Address.City = "Lyon"
+			},
+		},
+		{
+			name:  "Nil pointer targets are allocated",
+			patch: UserPatch{Billing: param.From(AddressPatch{Street: param.From("Main St")})},
+			want: func(u *User) {
+				u.Billing = &Address{Street: "Main St"}
+			},
+		},
+		{
+			name:  "Maps are merged and null entries deleted",
+			patch: UserPatch{Prefs: param.From(map[string]any{"theme": "light", "lang": nil, "tz": "UTC"})},
+			want: func(u *User) {
+				u.Prefs = map[string]any{"theme": "light", "tz": "UTC"}
+			},
+		},
+		{
+			name:  "Null object deletes it",
+			patch: UserPatch{Address: param.Null[AddressPatch](), Prefs: param.Null[map[string]any]()},
+			want: func(u *User) {
+				u.Address, u.Prefs = Address{}, nil
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, want := newUser(), newUser()
+			tc.want(&want)
+			if err := mergepatch.Apply(&got, tc.patch); err != nil {
+				t.Fatalf("Apply() returned an unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("Apply() got %+v, want %+v", got, want)
+			}
+		})
+	}
+}
+
+// TestApplyRFC7386 runs the examples from RFC 7386 Appendix A on generic maps.
+func TestApplyRFC7386(t *testing.T) {
+	testCases := []struct {
+		target, patch, want map[string]any
+	}{
+		{map[string]any{"a": "b"}, map[string]any{"a": "c"}, map[string]any{"a": "c"}},
+		{map[string]any{"a": "b"}, map[string]any{"b": "c"}, map[string]any{"a": "b", "b": "c"}},
+		{map[string]any{"a": "b"}, map[string]any{"a": nil}, map[string]any{}},
+		{map[string]any{"a": "b", "b": "c"}, map[string]any{"a": nil}, map[string]any{"b": "c"}},
+		{map[string]any{"a": []any{"b"}}, map[string]any{"a": "c"}, map[string]any{"a": "c"}},
+		{map[string]any{"a": "c"}, map[string]any{"a": []any{"b"}}, map[string]any{"a": []any{"b"}}},
+		{
+			map[string]any{"a": map[string]any{"b": "c"}},
+			map[string]any{"a": map[string]any{"b": "d", "c": nil}},
+			map[string]any{"a": map[string]any{"b": "d"}},
+		},
+		{
+			map[string]any{"a": []any{map[string]any{"b": "c"}}},
+			map[string]any{"a": []any{1}},
+			map[string]any{"a": []any{1}},
+		},
+		{map[string]any{"e": nil}, map[string]any{"a": 1}, map[string]any{"e": nil, "a": 1}},
+		{map[string]any{"a": "foo"}, map[string]any{"a": map[string]any{"bb": map[string]any{"ccc": nil}}}, map[string]any{"a": map[string]any{"bb": map[string]any{}}}},
+		{map[string]any{}, map[string]any{"a": map[string]any{"bb": map[string]any{"ccc": nil}}}, map[string]any{"a": map[string]any{"bb": map[string]any{}}}},
+	}
+
+	for _, tc := range testCases {
+		got := tc.target
+		if err := mergepatch.Apply(&got, tc.patch); err != nil {
+			t.Fatalf("Apply(%v, %v) returned an unexpected error: %v", tc.target, tc.patch, err)
+		}
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("Apply() got %v, want %v", got, tc.want)
+		}
+	}
+}
+
+// TestApplyErrors validates the error cases.
+func TestApplyErrors(t *testing.T) {
+	t.Run("Non-pointer target", func(t *testing.T) {
+		if err := mergepatch.Apply(User{}, UserPatch{}); err == nil {
+			t.Error("Expected an error for a non-pointer target but got nil")
+		}
+	})
+
+	t.Run("Unknown field", func(t *testing.T) {
+		patch := struct {
+			Email param.Opt[string] `json:"email"`
+		}{Email: param.From("a@example.com")}
+		if err := mergepatch.Apply(&User{}, patch); err == nil {
+			t.Error("Expected an error for an unknown field but got nil")
+		}
+	})
+
+	t.Run("Type mismatch", func(t *testing.T) {
+		patch := struct {
+			Name param.Opt[int] `json:"name"`
+		}{Name: param.From(1)}
+		if err := mergepatch.Apply(&User{}, patch); err == nil {
+			t.Error("Expected a type mismatch error but got nil")
+		}
+	})
+
+	t.Run("Lossy numeric conversion", func(t *testing.T) {
+		testCases := []struct {
+			name  string
+			patch map[string]any
+		}{
+			{name: "Float to int", patch: map[string]any{"age": 1.9}},
+			{name: "Int overflow", patch: map[string]any{"small": 300}},
+			{name: "Negative to uint", patch: map[string]any{"count": -1}},
+			{name: "Float32 overflow", patch: map[string]any{"ratio": 1e300}},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				var dst struct {
+					Age   int     `json:"age"`
+					Small int8    `json:"small"`
+					Count uint    `json:"count"`
+					Ratio float32 `json:"ratio"`
+				}
+				if err := mergepatch.Apply(&dst, tc.patch); err == nil {
+					t.Errorf("Expected a conversion error but got nil, stored %+v", dst)
+				}
+			})
+		}
+	})
+
+	t.Run("Lossless numeric conversion", func(t *testing.T) {
+		var dst struct {
+			Age   param.Opt[int] `json:"age"`
+			Small int8           `json:"small"`
+		}
+		if err := mergepatch.Apply(&dst, map[string]any{"age": 42.0, "small": int64(-128)}); err != nil {
+			t.Fatalf("Apply() returned an unexpected error: %v", err)
+		}
+		if v, _ := dst.Age.Get(); v != 42 || dst.Small != -128 {
+			t.Errorf("Apply() got age=%v small=%d, want 42 and -128", dst.Age, dst.Small)
+		}
+	})
+}
@@ -0,0 +1,71 @@
+//go:build go1.24
+
+package param_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/qntx/param"
+)
+
+// TestJSONOmitZero validates that unset fields are omitted by `omitzero`, like they
+// are by `omitempty`.
+func TestJSONOmitZero(t *testing.T) {
+	type Payload struct {
+		Empty param.Opt[int] `json:"empty,omitempty"`
+		Zero  param.Opt[int] `json:"zero,omitzero"`
+	}
+
+	testCases := []struct {
+		name  string
+		input Payload
+		want  string
+	}{
+		{
+			name:  "Unset fields are omitted by both tags",
+			input: Payload{Empty: param.Zero[int](), Zero: param.Zero[int]()},
+			want:  `{}`,
+		},
+		{
+			name:  "Nil fields are omitted by both tags",
+			input: Payload{},
+			want:  `{}`,
+		},
+		{
+			name:  "Null fields are kept by both tags",
+			input: Payload{Empty: param.Null[int](), Zero: param.Null[int]()},
+			want:  `{"empty":null,"zero":null}`,
+		},
+		{
+			name:  "Zero values are kept by both tags",
+			input: Payload{Empty: param.From(0), Zero: param.From(0)},
+			want:  `{"empty":0,"zero":0}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := json.Marshal(tc.input)
+			if err != nil {
+				t.Fatalf("json.Marshal() returned an unexpected error: %v", err)
+			}
+			assertJSONEquals(t, got, []byte(tc.want))
+		})
+	}
+}
+
+// TestValOmitZero validates that unset Val fields are omitted by `omitzero`.
+func TestValOmitZero(t *testing.T) {
+	type Payload struct {
+		Unset param.Val[int]    `json:"unset,omitzero"`
+		Null  param.Val[int]    `json:"null,omitzero"`
+		Value param.Val[string] `json:"value,omitzero"`
+	}
+
+	got, err := json.Marshal(Payload{Null: param.NullVal[int](), Value: param.ValOf("")})
+	if err != nil {
+		t.Fatalf("json.Marshal() returned an unexpected error: %v", err)
+	}
+	assertJSONEquals(t, got, []byte(`{"null":null,"value":""}`))
+}
@@ -0,0 +1,51 @@
+// Package openapi generates OpenAPI 3.1 (JSON Schema 2020-12) schemas from structs
+// of param.Opt fields.
+//
+// A field is required unless it is an Opt, which may be omitted, or is tagged
+// `omitempty` or `omitzero`. Opt fields, pointers, slices and maps accept null,
+// which is written as a type list such as ["string", "null"]. Named structs are
+// emitted once as components and referenced with $ref. The `param` validation
+// tags are honored: required adds the field to required, nonnull removes null
+// from its type, and min and max become the matching bound keywords.
+package openapi
+
+// MergePatch is the media type of JSON Merge Patch (RFC 7386) documents.
+const MergePatch = "application/merge-patch+json"
+
+// Schema is an OpenAPI 3.1 schema object.
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 any                `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	ContentEncoding      string             `json:"contentEncoding,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	AnyOf                []*Schema          `json:"anyOf,omitempty"`
+	Minimum              *float64           `json:"minimum,omitempty"`
+	Maximum              *float64           `json:"maximum,omitempty"`
+	MinLength            *int               `json:"minLength,omitempty"`
+	MaxLength            *int               `json:"maxLength,omitempty"`
+	MinItems             *int               `json:"minItems,omitempty"`
+	MaxItems             *int               `json:"maxItems,omitempty"`
+	MinProperties        *int               `json:"minProperties,omitempty"`
+	MaxProperties        *int               `json:"maxProperties,omitempty"`
+}
+
+// RequestBody is an OpenAPI request body object.
+type RequestBody struct {
+	Description string               `json:"description,omitempty"`
+	Required    bool                 `json:"required,omitempty"`
+	Content     map[string]MediaType `json:"content"`
+}
+
+// MediaType is an OpenAPI media type object.
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+// Components holds the reusable schemas of an OpenAPI document.
+type Components struct {
+	Schemas map[string]*Schema `json:"schemas,omitempty"`
+}
@@ -0,0 +1,130 @@
+package openapi_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/qntx/param"
+	"github.com/qntx/param/openapi"
+)
+
+type Address struct {
+	City param.Opt[string] `json:"city"`
+	Zip  string            `json:"zip"`
+}
+
+type UserPatch struct {
+	Name     param.Opt[string]    `json:"name" param:"nonnull,min=1"`
+	Age      param.Opt[int]       `json:"age" param:"min=0,max=150"`
+	Email    param.Opt[string]    `json:"email" param:"required"`
+	Tags     param.Opt[[]string]  `json:"tags"`
+	Birthday param.Opt[time.Time] `json:"birthday"`
+	Address  param.Opt[Address]   `json:"address"`
+	Billing  *Address             `json:"billing,omitempty"`
+	Score    float64              `json:"score"`
+	Avatar   []byte               `json:"avatar,omitempty"`
+	Extra    map[string]any       `json:"extra,omitzero"`
+	Manager  *UserPatch           `json:"manager,omitempty"`
+	Ignored  param.Opt[string]    `json:"-"`
+}
+
+func marshal(t *testing.T, v any) any {
+	t.Helper()
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("json.Marshal() returned an unexpected error: %v", err)
+	}
+	var out any
+	if err := json.Unmarshal(data, &out); err != nil {
+		t.Fatalf("json.Unmarshal() returned an unexpected error: %v", err)
+	}
+	return out
+}
+
+// TestSchema validates required and nullable handling and component references.
+func TestSchema(t *testing.T) {
+	g := openapi.NewGenerator()
+	s, err := g.Schema((*UserPatch)(nil))
+	if err != nil {
+		t.Fatalf("Schema() returned an unexpected error: %v", err)
+	}
+	if s.Ref != "#/components/schemas/UserPatch" {
+		t.Errorf("Schema() got $ref %q, want the UserPatch component", s.Ref)
+	}
+
+	got := marshal(t, g.Components())
+	want := marshal(t, json.RawMessage(`{"schemas": {
+		"Address": {
+			"type": "object",
+			"properties": {
+				"city": {"type": ["string", "null"]},
+				"zip": {"type": "string"}
+			},
+			"required": ["zip"]
+		},
+		"UserPatch": {
+			"type": "object",
+			"properties": {
+				"name": {"type": "string", "minLength": 1},
+				"age": {"type": ["integer", "null"], "minimum": 0, "maximum": 150},
+				"email": {"type": ["string", "null"]},
+				"tags": {"type": ["array", "null"], "items": {"type": "string"}},
+				"birthday": {"type": ["string", "null"], "format": "date-time"},
+				"address": {"anyOf": [{"$ref": "#/components/schemas/Address"}, {"type": "null"}]},
+				"billing": {"anyOf": [{"$ref": "#/components/schemas/Address"}, {"type": "null"}]},
+				"score": {"type": "number", "format": "double"},
+				"avatar": {"type": ["string", "null"], "contentEncoding": "base64"},
+				"extra": {"type": ["object", "null"], "additionalProperties": {}},
+				"manager": {"anyOf": [{"$ref": "#/components/schemas/UserPatch"}, {"type": "null"}]}
+			},
+			"required": ["email", "score"]
+		}
+	}}`))
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Components() got %v, want %v", got, want)
+	}
+}
+
+// TestMergePatchBody validates the PATCH request body.
+func TestMergePatchBody(t *testing.T) {
+	body, err := openapi.NewGenerator().MergePatchBody(Address{})
+	if err != nil {
+		t.Fatalf("MergePatchBody() returned an unexpected error: %v", err)
+	}
+
+	got := marshal(t, body)
+	want := marshal(t, json.RawMessage(`{
+		"required": true,
+		"content": {"application/merge-patch+json": {"schema": {"$ref": "#/components/schemas/Address"}}}
+	}`))
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("MergePatchBody() got %v, want %v", got, want)
+	}
+}
+
+// TestSchemaErrors validates the reported errors.
+func TestSchemaErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		v    any
+	}{
+		{"Nil", nil},
+		{"Unsupported", struct{ C chan int }{}},
+		{"UnknownRule", struct {
+			A param.Opt[int] `param:"positive"`
+		}{}},
+		{"BoundOnBool", struct {
+			A param.Opt[bool] `param:"min=1"`
+		}{}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := openapi.NewGenerator().Schema(tc.v); err == nil {
+				t.Error("Expected an error but got nil")
+			}
+		})
+	}
+}
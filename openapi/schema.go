@@ -0,0 +1,306 @@
+package openapi
+
+import (
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/qntx/param/internal/optreflect"
+	"github.com/qntx/param/internal/paramtag"
+)
+
+var (
+	timeType          = reflect.TypeOf(time.Time{})
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+
+	invalidName = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+)
+
+// Generator generates schemas, collecting the named structs they reference as
+// components.
+type Generator struct {
+	// RefPrefix is prepended to component names to form $ref values.
+	RefPrefix string
+
+	names   map[reflect.Type]string
+	schemas map[string]*Schema
+}
+
+// NewGenerator returns a Generator referencing components under
+// "#/components/schemas/".
+func NewGenerator() *Generator {
+	return &Generator{RefPrefix: "#/components/schemas/"}
+}
+
+// Schema returns the schema of the type of v, which may be a nil pointer such as
+// (*UserPatch)(nil). Named structs, including v itself, are added to the
+// components and referenced.
+func (g *Generator) Schema(v any) (*Schema, error) {
+	typ := reflect.TypeOf(v)
+	if typ == nil {
+		return nil, errors.New("openapi: cannot generate a schema for nil")
+	}
+	for typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	s, err := g.schema(typ)
+	if err != nil {
+		return nil, fmt.Errorf("openapi: %w", err)
+	}
+	return s, nil
+}
+
+// MergePatchBody returns a required request body accepting the type of v as a
+// JSON Merge Patch document, as used by PATCH operations.
+func (g *Generator) MergePatchBody(v any) (*RequestBody, error) {
+	s, err := g.Schema(v)
+	if err != nil {
+		return nil, err
+	}
+	return &RequestBody{
+		Required: true,
+		Content:  map[string]MediaType{MergePatch: {Schema: s}},
+	}, nil
+}
+
+// Components returns the schemas referenced so far, keyed by component name.
+func (g *Generator) Components() Components {
+	return Components{Schemas: g.schemas}
+}
+
+func (g *Generator) schema(typ reflect.Type) (*Schema, error) {
+	switch {
+	case optreflect.Is(typ):
+		s, err := g.schema(optreflect.Elem(typ))
+		if err != nil {
+			return nil, err
+		}
+		return nullable(s), nil
+	case typ == timeType:
+		return &Schema{Type: "string", Format: "date-time"}, nil
+	case implements(typ, jsonMarshalerType):
+		return &Schema{}, nil
+	case implements(typ, textMarshalerType):
+		return &Schema{Type: "string"}, nil
+	}
+
+	switch typ.Kind() {
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uintptr:
+		return &Schema{Type: "integer"}, nil
+	case reflect.Int32, reflect.Uint32:
+		return &Schema{Type: "integer", Format: "int32"}, nil
+	case reflect.Int64, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "int64"}, nil
+	case reflect.Float32:
+		return &Schema{Type: "number", Format: "float"}, nil
+	case reflect.Float64:
+		return &Schema{Type: "number", Format: "double"}, nil
+	case reflect.String:
+		return &Schema{Type: "string"}, nil
+	case reflect.Interface:
+		return &Schema{}, nil
+	case reflect.Pointer:
+		s, err := g.schema(typ.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return nullable(s), nil
+	case reflect.Slice, reflect.Array:
+		if typ.Elem().Kind() == reflect.Uint8 && !implements(typ.Elem(), jsonMarshalerType) && !implements(typ.Elem(), textMarshalerType) {
+			s := &Schema{Type: "string", ContentEncoding: "base64"}
+			if typ.Kind() == reflect.Slice {
+				s = nullable(s)
+			}
+			return s, nil
+		}
+		items, err := g.schema(typ.Elem())
+		if err != nil {
+			return nil, err
+		}
+		if typ.Kind() == reflect.Array {
+			n := typ.Len()
+			return &Schema{Type: "array", Items: items, MinItems: &n, MaxItems: &n}, nil
+		}
+		return nullable(&Schema{Type: "array", Items: items}), nil
+	case reflect.Map:
+		switch typ.Key().Kind() {
+		case reflect.String, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		default:
+			if !implements(typ.Key(), textMarshalerType) {
+				return nil, fmt.Errorf("unsupported map key type %s", typ.Key())
+			}
+		}
+		values, err := g.schema(typ.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return nullable(&Schema{Type: "object", AdditionalProperties: values}), nil
+	case reflect.Struct:
+		if typ.Name() == "" {
+			return g.object(typ)
+		}
+		return g.ref(typ)
+	}
+	return nil, fmt.Errorf("unsupported type %s", typ)
+}
+
+// ref returns a reference to the component of the named struct typ, generating it
+// on first use.
+func (g *Generator) ref(typ reflect.Type) (*Schema, error) {
+	if name, ok := g.names[typ]; ok {
+		return &Schema{Ref: g.RefPrefix + name}, nil
+	}
+	name := strings.Trim(invalidName.ReplaceAllString(typ.Name(), "_"), "_")
+	if _, taken := g.schemas[name]; taken {
+		return nil, fmt.Errorf("component name %s of %s is already used by another type", name, typ)
+	}
+	if g.names == nil {
+		g.names = map[reflect.Type]string{}
+		g.schemas = map[string]*Schema{}
+	}
+	// Register the component before generating it so recursive types terminate.
+	s := &Schema{}
+	g.names[typ] = name
+	g.schemas[name] = s
+	obj, err := g.object(typ)
+	if err != nil {
+		delete(g.names, typ)
+		delete(g.schemas, name)
+		return nil, err
+	}
+	*s = *obj
+	return &Schema{Ref: g.RefPrefix + name}, nil
+}
+
+// object returns the inline schema of the struct typ.
+func (g *Generator) object(typ reflect.Type) (*Schema, error) {
+	obj := &Schema{Type: "object", Properties: map[string]*Schema{}}
+	for _, f := range optreflect.Fields(typ, "json") {
+		s, err := g.schema(f.Type)
+		if err != nil {
+			return nil, fmt.Errorf("%s.%s: %w", typ.Name(), f.Name, err)
+		}
+		required := !optreflect.Is(f.Type) &&
+			!slices.Contains(f.Opts, "omitempty") && !slices.Contains(f.Opts, "omitzero")
+		if tag, ok := f.Tag.Lookup("param"); ok {
+			if required, err = rules(tag, s, required); err != nil {
+				return nil, fmt.Errorf("%s.%s: %w", typ.Name(), f.Name, err)
+			}
+		}
+		obj.Properties[f.Key] = s
+		if required {
+			obj.Required = append(obj.Required, f.Key)
+		}
+	}
+	return obj, nil
+}
+
+// rules applies the validate rules of tag to the field schema s and returns
+// whether the field is required.
+func rules(tag string, s *Schema, required bool) (bool, error) {
+	r, err := paramtag.Parse(tag)
+	if err != nil {
+		return false, err
+	}
+	if r.NonNull {
+		nonnull(s)
+	}
+	if r.Min != nil {
+		if err := limit(s, true, r.Min.Value); err != nil {
+			return false, err
+		}
+	}
+	if r.Max != nil {
+		if err := limit(s, false, r.Max.Value); err != nil {
+			return false, err
+		}
+	}
+	return required || r.Required, nil
+}
+
+// limit sets the lower or upper bound keyword matching the type of s.
+func limit(s *Schema, lower bool, bound float64) error {
+	n := int(bound)
+	var count **int
+	switch baseType(s) {
+	case "integer", "number":
+		if lower {
+			s.Minimum = &bound
+		} else {
+			s.Maximum = &bound
+		}
+		return nil
+	case "string":
+		count = &s.MaxLength
+		if lower {
+			count = &s.MinLength
+		}
+	case "array":
+		count = &s.MaxItems
+		if lower {
+			count = &s.MinItems
+		}
+	case "object":
+		count = &s.MaxProperties
+		if lower {
+			count = &s.MinProperties
+		}
+	default:
+		return fmt.Errorf("min and max are not supported on %v", s.Type)
+	}
+	*count = &n
+	return nil
+}
+
+// baseType returns the type of s other than "null".
+func baseType(s *Schema) string {
+	switch t := s.Type.(type) {
+	case string:
+		return t
+	case []string:
+		for _, typ := range t {
+			if typ != "null" {
+				return typ
+			}
+		}
+	}
+	return ""
+}
+
+// nullable returns s extended to accept null.
+func nullable(s *Schema) *Schema {
+	switch t := s.Type.(type) {
+	case string:
+		s.Type = []string{t, "null"}
+	case nil:
+		// A schema without a type already accepts null unless it is a reference.
+		if s.Ref != "" {
+			return &Schema{AnyOf: []*Schema{s, {Type: "null"}}}
+		}
+	}
+	return s
+}
+
+// nonnull removes null from the accepted types of s.
+func nonnull(s *Schema) {
+	if _, ok := s.Type.([]string); ok {
+		s.Type = baseType(s)
+	}
+	if len(s.AnyOf) == 2 && s.AnyOf[1].Type == "null" {
+		*s = *s.AnyOf[0]
+	}
+}
+
+func implements(typ, iface reflect.Type) bool {
+	return typ.Implements(iface) || reflect.PointerTo(typ).Implements(iface)
+}
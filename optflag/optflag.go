@@ -0,0 +1,98 @@
+// Package optflag exposes param.Opt fields as command-line flags, so a CLI can tell
+// a flag that was not passed (unset) apart from `--name=` (empty value) and
+// `--name=null` (null).
+package optflag
+
+import (
+	"flag"
+	"fmt"
+	"reflect"
+
+	"github.com/qntx/param"
+	"github.com/qntx/param/internal/optreflect"
+)
+
+// Value adapts a tri-state field to flag.Value. It also implements the Type method
+// of pflag.Value, so it can be registered on spf13/pflag flag sets.
+type Value struct {
+	opt  param.TextOpt
+	typ  reflect.Type
+	null string
+}
+
+// Ensure Value implements flag.Value and flag.Getter
+var _ flag.Getter = (*Value)(nil)
+
+// Var returns a flag.Value setting opt, such as a *param.Opt[int], with values
+// parsed as text and param.NullToken meaning null.
+func Var(opt param.TextOpt) *Value {
+	return VarNull(opt, param.NullToken)
+}
+
+// VarNull is like Var but uses null as the null token.
+func VarNull(opt param.TextOpt, null string) *Value {
+	return &Value{opt: opt, typ: optreflect.Elem(reflect.TypeOf(opt).Elem()), null: null}
+}
+
+// String returns the flag value as text: empty if unset, the null token if null.
+func (v *Value) String() string {
+	if v == nil || v.opt == nil || !v.opt.IsSet() {
+		return ""
+	}
+	b, err := v.opt.MarshalTextNull(v.null)
+	if err != nil {
+		return ""
+	}
+	return string(b)
+}
+
+// Set parses s into the field.
+func (v *Value) Set(s string) error {
+	return v.opt.UnmarshalTextNull([]byte(s), v.null)
+}
+
+// Get returns the underlying field.
+func (v *Value) Get() any {
+	return v.opt
+}
+
+// Type returns the name of the value type, e.g. "int" or "time.Time".
+func (v *Value) Type() string {
+	return v.typ.String()
+}
+
+// IsBoolFlag reports whether the flag can be passed without a value, as for bools.
+func (v *Value) IsBoolFlag() bool {
+	return v.typ.Kind() == reflect.Bool
+}
+
+// Register defines a flag on fs for every Opt field of the struct pointed to by dst.
+// Flag names come from the `flag` tag, then the `json` tag, then the field name, and
+// the usage from the `usage` tag. Fields of nested structs are prefixed with the
+// struct's name and a dot, e.g. "address.city".
+func Register(fs *flag.FlagSet, dst any) error {
+	v := reflect.ValueOf(dst)
+	if v.Kind() != reflect.Pointer || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("optflag: dst must be a non-nil pointer to a struct, got %T", dst)
+	}
+	register(fs, v.Elem(), "")
+	return nil
+}
+
+func register(fs *flag.FlagSet, v reflect.Value, prefix string) {
+	for _, f := range optreflect.Fields(v.Type(), "flag", "json") {
+		fv, err := v.FieldByIndexErr(f.Index)
+		if err != nil {
+			continue
+		}
+		name := prefix + f.Key
+		switch {
+		case optreflect.Is(f.Type):
+			if opt, ok := fv.Addr().Interface().(param.TextOpt); ok {
+				fs.Var(Var(opt), name, f.Tag.Get("usage"))
+			}
+		case f.Type.Kind() == reflect.Struct && optreflect.IsObject(f.Type):
+			register(fs, fv, name+".")
+		}
+	}
+}
@@ -0,0 +1,121 @@
+package optflag_test
+
+import (
+	"flag"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/qntx/param"
+	"github.com/qntx/param/optflag"
+)
+
+type Address struct {
+	City param.Opt[string] `flag:"city" usage:"city name"`
+}
+
+type UserPatch struct {
+	Name    param.Opt[string]        `flag:"name" usage:"user name"`
+	Age     param.Opt[int]           `json:"age"`
+	Admin   param.Val[bool]          `flag:"admin"`
+	Timeout param.Opt[time.Duration] `flag:"-"`
+	Address Address                  `flag:"address"`
+	ID      int                      `flag:"id"`
+}
+
+func parse(t *testing.T, args ...string) UserPatch {
+	t.Helper()
+	var p UserPatch
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	if err := optflag.Register(fs, &p); err != nil {
+		t.Fatalf("Register() returned an unexpected error: %v", err)
+	}
+	if err := fs.Parse(args); err != nil {
+		t.Fatalf("Parse() returned an unexpected error: %v", err)
+	}
+	return p
+}
+
+// TestRegister validates the tri-state parsing of registered flags.
+func TestRegister(t *testing.T) {
+	t.Run("Flags not passed are unset", func(t *testing.T) {
+		p := parse(t)
+		if p.Name.IsSet() || p.Age.IsSet() || p.Admin.IsSet() || p.Address.City.IsSet() {
+			t.Errorf("Parse() got %+v, want every field unset", p)
+		}
+	})
+
+	t.Run("Empty value", func(t *testing.T) {
+		p := parse(t, "--name=")
+		if v, ok := p.Name.Get(); !ok || v != "" {
+			t.Errorf("Name got (%q, %v), want (\"\", true)", v, ok)
+		}
+	})
+
+	t.Run("Null value", func(t *testing.T) {
+		p := parse(t, "--name=null", "-age", "null")
+		if !p.Name.IsNull() || !p.Age.IsNull() {
+			t.Errorf("Parse() got name=%v age=%v, want both null", p.Name, p.Age)
+		}
+	})
+
+	t.Run("Values, bool flags and nested structs", func(t *testing.T) {
+		p := parse(t, "-age=42", "-admin", "-address.city", "Paris")
+		if p.Age.MustGet() != 42 || !p.Admin.MustGet() || p.Address.City.MustGet() != "Paris" {
+			t.Errorf("Parse() got %+v", p)
+		}
+	})
+}
+
+// TestRegisterFlags validates the registered names, usages and types.
+func TestRegisterFlags(t *testing.T) {
+	var p UserPatch
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	if err := optflag.Register(fs, &p); err != nil {
+		t.Fatalf("Register() returned an unexpected error: %v", err)
+	}
+
+	want := map[string]string{"name": "string", "age": "int", "admin": "bool", "address.city": "string"}
+	got := map[string]string{}
+	fs.VisitAll(func(f *flag.Flag) {
+		got[f.Name] = f.Value.(*optflag.Value).Type()
+	})
+	if len(got) != len(want) {
+		t.Errorf("Register() defined %v, want %v", got, want)
+	}
+	for name, typ := range want {
+		if got[name] != typ {
+			t.Errorf("Type() of %q got %q, want %q", name, got[name], typ)
+		}
+	}
+	if usage := fs.Lookup("name").Usage; usage != "user name" {
+		t.Errorf("Usage got %q, want \"user name\"", usage)
+	}
+
+	if err := optflag.Register(fs, p); err == nil {
+		t.Error("Expected an error for a non-pointer destination but got nil")
+	}
+}
+
+// TestVar validates a single adapted flag.
+func TestVar(t *testing.T) {
+	var timeout param.Opt[time.Duration]
+	v := optflag.VarNull(&timeout, "none")
+
+	if v.String() != "" {
+		t.Errorf("String() on unset got %q, want \"\"", v.String())
+	}
+	if err := v.Set("none"); err != nil || !timeout.IsNull() || v.String() != "none" {
+		t.Errorf("Set(\"none\") got (null=%v, %v), want null", timeout.IsNull(), err)
+	}
+	if err := v.Set("1m30s"); err != nil || timeout.MustGet() != 90*time.Second || v.String() != "1m30s" {
+		t.Errorf("Set(\"1m30s\") got (%v, %v), want 1m30s", timeout, err)
+	}
+	if err := v.Set("abc"); err == nil {
+		t.Error("Expected a parse error but got nil")
+	}
+	if v.Type() != "time.Duration" || v.IsBoolFlag() {
+		t.Errorf("Type() got %q, IsBoolFlag() got %v", v.Type(), v.IsBoolFlag())
+	}
+}
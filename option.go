@@ -86,6 +86,11 @@ func (t *Opt[T]) Reset() {
 	*t = map[bool]T{}
 }
 
+// IsZero indicates whether the field is unset, so it is omitted by `omitzero`.
+func (t Opt[T]) IsZero() bool {
+	return !t.IsSet()
+}
+
 func (t Opt[T]) MarshalJSON() ([]byte, error) {
 	if t.IsNull() {
 		return []byte("null"), nil
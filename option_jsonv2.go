@@ -0,0 +1,76 @@
+// The go1.27 term is required, not just the experiment. Go 1.27 records the
+// encoding/json/v2 API as added in Go 1.27; go build accepts it in this go1.22
+// module, but vet's stdversion check rejects every use, and go test runs that
+// check, so the package would fail to test. The term raises this file to go1.27.
+// A build constraint can only raise the version of a whole file, so Go 1.25 and
+// 1.26 toolchains with GOEXPERIMENT=jsonv2 build without this file and json/v2
+// uses the MarshalJSON and UnmarshalJSON methods instead.
+//
+// Without the experiment this file and option_jsonv2_test.go are not compiled at
+// all; the jsonv2 CI workflow tests them with GOEXPERIMENT=jsonv2.
+//go:build goexperiment.jsonv2 && go1.27
+
+package param
+
+import (
+	"encoding/json/jsontext"
+	jsonv2 "encoding/json/v2"
+)
+
+// Ensure Opt implements the encoding/json/v2 streaming interfaces
+var _ jsonv2.MarshalerTo = (*Opt[any])(nil)
+var _ jsonv2.UnmarshalerFrom = (*Opt[any])(nil)
+
+func (t Opt[T]) MarshalJSONTo(enc *jsontext.Encoder) error {
+	if t.IsNull() {
+		return enc.WriteToken(jsontext.Null)
+	}
+
+	// if field was unspecified, and `omitzero` is set on the field's tags, `json.Marshal` will omit this field
+
+	// otherwise: we have a value, so marshal it
+	return jsonv2.MarshalEncode(enc, t[true])
+}
+
+func (t *Opt[T]) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	if dec.PeekKind() == 'n' {
+		if _, err := dec.ReadToken(); err != nil {
+			return err
+		}
+		t.SetNull()
+		return nil
+	}
+	var v T
+	if err := jsonv2.UnmarshalDecode(dec, &v); err != nil {
+		return err
+	}
+	t.Set(v)
+	return nil
+}
+
+// Ensure Val implements the encoding/json/v2 streaming interfaces
+var _ jsonv2.MarshalerTo = (*Val[any])(nil)
+var _ jsonv2.UnmarshalerFrom = (*Val[any])(nil)
+
+func (t Val[T]) MarshalJSONTo(enc *jsontext.Encoder) error {
+	if t.state == stateNull {
+		return enc.WriteToken(jsontext.Null)
+	}
+	return jsonv2.MarshalEncode(enc, t.v)
+}
+
+func (t *Val[T]) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	if dec.PeekKind() == 'n' {
+		if _, err := dec.ReadToken(); err != nil {
+			return err
+		}
+		t.SetNull()
+		return nil
+	}
+	var v T
+	if err := jsonv2.UnmarshalDecode(dec, &v); err != nil {
+		return err
+	}
+	t.Set(v)
+	return nil
+}
@@ -0,0 +1,117 @@
+// See option_jsonv2.go for why the go1.27 term is required.
+//go:build goexperiment.jsonv2 && go1.27
+
+package param_test
+
+import (
+	jsonv2 "encoding/json/v2"
+	"testing"
+
+	"github.com/qntx/param"
+)
+
+// TestJSONv2Marshaling validates the encoding/json/v2 serialization logic.
+func TestJSONv2Marshaling(t *testing.T) {
+	type Payload struct {
+		Required param.Opt[string] `json:"required"`
+		Optional param.Opt[int]    `json:"optional,omitzero"`
+		Always   param.Opt[bool]   `json:"always"`
+	}
+
+	testCases := []struct {
+		name  string
+		input Payload
+		want  string
+	}{
+		{
+			name: "All fields valid",
+			input: Payload{
+				Required: param.From("hello"),
+				Optional: param.From(123),
+				Always:   param.From(true),
+			},
+			want: `{"required":"hello","optional":123,"always":true}`,
+		},
+		{
+			name: "Optional field is unset and omitted",
+			input: Payload{
+				Required: param.From("world"),
+				Always:   param.Null[bool](),
+			},
+			want: `{"required":"world","always":null}`,
+		},
+		{
+			name: "Required field is unset (marshals to zero value)",
+			input: Payload{
+				Optional: param.From(0),
+				Always:   param.From(false),
+			},
+			want: `{"required":"","optional":0,"always":false}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := jsonv2.Marshal(tc.input)
+			if err != nil {
+				t.Fatalf("json.Marshal() returned an unexpected error: %v", err)
+			}
+			assertJSONEquals(t, got, []byte(tc.want))
+		})
+	}
+}
+
+// TestJSONv2Unmarshaling validates the encoding/json/v2 deserialization logic.
+func TestJSONv2Unmarshaling(t *testing.T) {
+	type Payload struct {
+		Required param.Opt[string] `json:"required"`
+		Optional param.Opt[int]    `json:"optional"`
+	}
+
+	t.Run("All fields present and valid", func(t *testing.T) {
+		var p Payload
+		if err := jsonv2.Unmarshal([]byte(`{"required":"hello","optional":123}`), &p); err != nil {
+			t.Fatalf("json.Unmarshal() failed: %v", err)
+		}
+		if p.Required.MustGet() != "hello" || p.Optional.MustGet() != 123 {
+			t.Error("Did not unmarshal valid values correctly")
+		}
+	})
+
+	t.Run("Optional field is missing", func(t *testing.T) {
+		var p Payload
+		if err := jsonv2.Unmarshal([]byte(`{"required":"world"}`), &p); err != nil {
+			t.Fatalf("json.Unmarshal() failed: %v", err)
+		}
+		if p.Optional.IsSet() {
+			t.Error("A missing field should be unset")
+		}
+	})
+
+	t.Run("Fields are explicitly null", func(t *testing.T) {
+		var p Payload
+		if err := jsonv2.Unmarshal([]byte(`{"required":null,"optional":null}`), &p); err != nil {
+			t.Fatalf("json.Unmarshal() failed: %v", err)
+		}
+		if !p.Required.IsNull() || !p.Optional.IsNull() {
+			t.Error("Fields should be Null after unmarshaling JSON null")
+		}
+	})
+
+	t.Run("Type mismatch error", func(t *testing.T) {
+		var p Payload
+		if err := jsonv2.Unmarshal([]byte(`{"required":123}`), &p); err == nil {
+			t.Fatal("Expected a type mismatch error but got nil")
+		}
+	})
+
+	t.Run("Val replaces the previous value", func(t *testing.T) {
+		m := param.ValOf(map[string]int{"a": 1})
+		if err := jsonv2.Unmarshal([]byte(`{"b":2}`), &m); err != nil {
+			t.Fatalf("json.Unmarshal() failed: %v", err)
+		}
+		if got := m.MustGet(); len(got) != 1 || got["b"] != 2 {
+			t.Errorf("json.Unmarshal() got %v, want map[b:2]", got)
+		}
+	})
+}
@@ -50,7 +50,7 @@ func TestConstructors(t *testing.T) {
 	})
 }
 
-// TestStateChecks validates the IsSet and IsNull methods across all states.
+// TestStateChecks validates the IsSet, IsNull and IsZero methods across all states.
 func TestStateChecks(t *testing.T) {
 	testCases := []struct {
 		name        string
@@ -92,6 +92,9 @@ func TestStateChecks(t *testing.T) {
 			if got := tc.n.IsNull(); got != tc.isNull {
 				t.Errorf("IsNull() got %v, want %v", got, tc.isNull)
 			}
+			if got := tc.n.IsZero(); got != !tc.isSpecified {
+				t.Errorf("IsZero() got %v, want %v", got, !tc.isSpecified)
+			}
 		})
 	}
 }
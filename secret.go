@@ -0,0 +1,397 @@
+package param
+
+import (
+	"bytes"
+	"database/sql"
+	"database/sql/driver"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"reflect"
+
+	"github.com/qntx/param/internal/optreflect"
+)
+
+// RedactedMarker replaces the value of a Secret in formatted and logged output, and
+// in the output of MarshalRedacted.
+const RedactedMarker = "[REDACTED]"
+
+// Secret is an Opt for sensitive values such as passwords and tokens. It has the
+// same three states and decodes like Opt, but its value never appears in fmt or
+// slog output, nor in MarshalRedacted output. It is marshaled as is otherwise, so
+// it can be forwarded. Get, Reveal and Value give explicit access to it.
+type Secret[T any] Opt[T]
+
+// Ensure Secret implements TextOpt, json.Marshaler, json.Unmarshaler, sql.Scanner,
+// driver.Valuer, fmt.Formatter and slog.LogValuer
+var _ TextOpt = (*Secret[any])(nil)
+var _ json.Marshaler = (*Secret[any])(nil)
+var _ json.Unmarshaler = (*Secret[any])(nil)
+var _ encoding.TextMarshaler = (*Secret[any])(nil)
+var _ encoding.TextUnmarshaler = (*Secret[any])(nil)
+var _ sql.Scanner = (*Secret[any])(nil)
+var _ driver.Valuer = Secret[any](nil)
+var _ fmt.Formatter = Secret[any](nil)
+var _ slog.LogValuer = Secret[any](nil)
+
+// SecretOf constructs a Secret[T] with the given value.
+func SecretOf[T any](value T) Secret[T] {
+	return Secret[T](From(value))
+}
+
+// NullSecret constructs a Secret[T] with an explicit `null`.
+func NullSecret[T any]() Secret[T] {
+	return Secret[T](Null[T]())
+}
+
+// Reveal returns the secret as an Opt, whose value is formatted, logged and
+// marshaled as is.
+func (t Secret[T]) Reveal() Opt[T] {
+	return Opt[T](t)
+}
+
+// Get retrieves the secret value, if present, and returns an empty value and `false` if not present.
+func (t Secret[T]) Get() (T, bool) {
+	return Opt[T](t).Get()
+}
+
+// Set sets the secret value.
+func (t *Secret[T]) Set(value T) {
+	(*Opt[T])(t).Set(value)
+}
+
+// IsNull indicates whether the field was sent as `null`.
+func (t Secret[T]) IsNull() bool {
+	return Opt[T](t).IsNull()
+}
+
+// SetNull sets the field to an explicit `null`.
+func (t *Secret[T]) SetNull() {
+	(*Opt[T])(t).SetNull()
+}
+
+// IsSet indicates whether the field was sent, either as `null` or a value.
+func (t Secret[T]) IsSet() bool {
+	return Opt[T](t).IsSet()
+}
+
+// Reset sets the field to the unset state.
+func (t *Secret[T]) Reset() {
+	(*Opt[T])(t).Reset()
+}
+
+// IsZero reports whether the field is unset, so `omitzero` omits it.
+func (t Secret[T]) IsZero() bool {
+	return !t.IsSet()
+}
+
+// MarshalJSON implements json.Marshaler like Opt.MarshalJSON, revealing the value.
+// Use MarshalRedacted to write RedactedMarker instead.
+func (t Secret[T]) MarshalJSON() ([]byte, error) {
+	return Opt[T](t).MarshalJSON()
+}
+
+// UnmarshalJSON implements json.Unmarshaler like Opt.UnmarshalJSON.
+func (t *Secret[T]) UnmarshalJSON(data []byte) error {
+	return (*Opt[T])(t).UnmarshalJSON(data)
+}
+
+// MarshalText implements encoding.TextMarshaler like MarshalTextNull with NullToken.
+func (t Secret[T]) MarshalText() ([]byte, error) {
+	return t.MarshalTextNull(NullToken)
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler like Opt.UnmarshalText.
+func (t *Secret[T]) UnmarshalText(text []byte) error {
+	return t.UnmarshalTextNull(text, NullToken)
+}
+
+// MarshalTextNull formats the field like Opt.MarshalTextNull, revealing the value.
+func (t Secret[T]) MarshalTextNull(null string) ([]byte, error) {
+	return Opt[T](t).MarshalTextNull(null)
+}
+
+// UnmarshalTextNull parses text like Opt.UnmarshalTextNull.
+func (t *Secret[T]) UnmarshalTextNull(text []byte, null string) error {
+	return (*Opt[T])(t).UnmarshalTextNull(text, null)
+}
+
+// Scan implements sql.Scanner like Opt.Scan.
+func (t *Secret[T]) Scan(src any) error {
+	return (*Opt[T])(t).Scan(src)
+}
+
+// Value implements driver.Valuer like Opt.Value, revealing the value.
+func (t Secret[T]) Value() (driver.Value, error) {
+	return Opt[T](t).Value()
+}
+
+// LogValue implements slog.LogValuer like Opt.LogValue, with RedactedMarker in
+// place of the value.
+func (t Secret[T]) LogValue() slog.Value {
+	if t.has() {
+		return slog.StringValue(RedactedMarker)
+	}
+	return Opt[T](t).LogValue()
+}
+
+// String implements fmt.Stringer, returning RedactedMarker, NullToken or
+// UnsetMarker.
+func (t Secret[T]) String() string {
+	return fmt.Sprint(t)
+}
+
+// GoString implements fmt.GoStringer, returning param.SecretOf[T]([REDACTED]),
+// param.NullSecret[T]() or param.Secret[T]{}.
+func (t Secret[T]) GoString() string {
+	switch {
+	case t.has():
+		return "param.SecretOf[" + typeName[T]() + "](" + RedactedMarker + ")"
+	case t.IsNull():
+		return "param.NullSecret[" + typeName[T]() + "]()"
+	}
+	return "param.Secret[" + typeName[T]() + "]{}"
+}
+
+// Format implements fmt.Formatter, writing RedactedMarker, NullToken or
+// UnsetMarker whatever the verb, and GoString for %#v.
+func (t Secret[T]) Format(f fmt.State, verb rune) {
+	if verb == 'v' && f.Flag('#') {
+		fmt.Fprint(f, t.GoString())
+		return
+	}
+	marker := UnsetMarker
+	switch {
+	case t.has():
+		marker = RedactedMarker
+	case t.IsNull():
+		marker = NullToken
+	}
+	fmt.Fprintf(f, fmt.FormatString(f, 's'), marker)
+}
+
+// secret marks Secret for LogGroup.
+func (t Secret[T]) secret() {}
+
+// has reports whether the secret holds a value.
+func (t Secret[T]) has() bool {
+	_, ok := t.Get()
+	return ok
+}
+
+// secretOpt is implemented by every Secret.
+type secretOpt interface {
+	secret()
+	IsNull() bool
+	IsSet() bool
+}
+
+var (
+	secretOptType     = reflect.TypeOf((*secretOpt)(nil)).Elem()
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// MarshalRedacted returns the JSON encoding of v like json.Marshal, with every
+// Secret holding a value written as RedactedMarker, e.g. for audit records. Only
+// this call is affected, so the same values can still be marshaled with their
+// secrets, e.g. to forward a request, at the same time. Secrets are found in
+// struct fields, Opt values, pointers, interfaces, slices, arrays and maps; values
+// of other types implementing json.Marshaler or encoding.TextMarshaler are written
+// as they marshal themselves. An error is returned rather than a member left
+// unredacted if a member cannot be matched to the value it was marshaled from.
+func MarshalRedacted(v any) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return redact(reflect.ValueOf(v), data)
+}
+
+// redact replaces the set secrets of v in data, its JSON encoding.
+func redact(v reflect.Value, data []byte) ([]byte, error) {
+	if !v.IsValid() || !holdsSecret(v.Type(), map[reflect.Type]bool{}) {
+		return data, nil
+	}
+	typ := v.Type()
+	switch {
+	case typ.Implements(secretOptType):
+		if s := v.Interface().(secretOpt); s.IsSet() && !s.IsNull() {
+			return json.Marshal(RedactedMarker)
+		}
+		return data, nil
+	case optreflect.Is(typ):
+		val, ok := optreflect.Get(v)
+		if !ok {
+			return data, nil
+		}
+		return redact(val, data)
+	}
+
+	switch typ.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			return data, nil
+		}
+		return redact(v.Elem(), data)
+	case reflect.Struct:
+		fields := map[string][]int{}
+		jsonFields(fields, typ, nil)
+		return redactObject(typ, data, func(key string) (reflect.Value, bool) {
+			index, ok := fields[key]
+			if !ok {
+				return reflect.Value{}, false
+			}
+			fv, err := v.FieldByIndexErr(index)
+			return fv, err == nil
+		})
+	case reflect.Map:
+		if v.IsNil() {
+			return data, nil
+		}
+		entries := make(map[string]reflect.Value, v.Len())
+		for iter := v.MapRange(); iter.Next(); {
+			key, err := jsonKey(iter.Key())
+			if err != nil {
+				return nil, err
+			}
+			entries[key] = iter.Value()
+		}
+		return redactObject(typ, data, func(key string) (reflect.Value, bool) {
+			e, ok := entries[key]
+			return e, ok
+		})
+	case reflect.Slice, reflect.Array:
+		if typ.Kind() == reflect.Slice && v.IsNil() {
+			return data, nil
+		}
+		var elems []json.RawMessage
+		if err := json.Unmarshal(data, &elems); err != nil {
+			return nil, err
+		}
+		for i := range elems {
+			e, err := redact(v.Index(i), elems[i])
+			if err != nil {
+				return nil, err
+			}
+			elems[i] = e
+		}
+		return json.Marshal(elems)
+	}
+	return data, nil
+}
+
+// redactObject redacts each member of the JSON object data, the encoding of a value
+// of type typ, with the value returned by field for its key, keeping the member
+// order.
+func redactObject(typ reflect.Type, data []byte, field func(key string) (reflect.Value, bool)) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if _, err := dec.Token(); err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err != nil {
+			return nil, err
+		}
+		key, _ := tok.(string)
+		fv, ok := field(key)
+		if !ok {
+			return nil, fmt.Errorf("param: cannot redact member %q of %s", key, typ)
+		}
+		if raw, err = redact(fv, raw); err != nil {
+			return nil, err
+		}
+		name, _ := json.Marshal(key)
+		if buf.Len() > 1 {
+			buf.WriteByte(',')
+		}
+		buf.Write(name)
+		buf.WriteByte(':')
+		buf.Write(raw)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// jsonKey returns the object key encoding/json writes for the map key k, by
+// marshaling a map holding only that key.
+func jsonKey(k reflect.Value) (string, error) {
+	m := reflect.MakeMapWithSize(reflect.MapOf(k.Type(), reflect.TypeOf(0)), 1)
+	m.SetMapIndex(k, reflect.ValueOf(0))
+	data, err := json.Marshal(m.Interface())
+	if err != nil {
+		return "", err
+	}
+	var obj map[string]int
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return "", err
+	}
+	for key := range obj {
+		return key, nil
+	}
+	return "", fmt.Errorf("param: cannot encode map key %v", k)
+}
+
+// jsonFields adds the index of every field of the struct typ to fields by JSON
+// name, flattening untagged embedded struct pointers like encoding/json. Fields
+// already present take precedence.
+func jsonFields(fields map[string][]int, typ reflect.Type, prefix []int) {
+	var embedded []reflect.StructField
+	for _, f := range optreflect.Fields(typ, "json") {
+		index := append(append([]int(nil), prefix...), f.Index...)
+		if _, tagged := f.Tag.Lookup("json"); f.Anonymous && !tagged &&
+			f.Type.Kind() == reflect.Pointer && f.Type.Elem().Kind() == reflect.Struct {
+			f.Index = index
+			embedded = append(embedded, f.StructField)
+			continue
+		}
+		if _, ok := fields[f.Key]; !ok {
+			fields[f.Key] = index
+		}
+	}
+	for _, f := range embedded {
+		jsonFields(fields, f.Type.Elem(), f.Index)
+	}
+}
+
+// holdsSecret reports whether values of typ may hold a Secret that json.Marshal
+// reaches.
+func holdsSecret(typ reflect.Type, seen map[reflect.Type]bool) bool {
+	switch {
+	case typ.Implements(secretOptType):
+		return true
+	case seen[typ]:
+		return false
+	}
+	seen[typ] = true
+	switch {
+	case optreflect.Is(typ):
+		return holdsSecret(optreflect.Elem(typ), seen)
+	case typ.Implements(jsonMarshalerType), typ.Implements(textMarshalerType),
+		reflect.PointerTo(typ).Implements(jsonMarshalerType), reflect.PointerTo(typ).Implements(textMarshalerType):
+		return false
+	}
+	switch typ.Kind() {
+	case reflect.Interface:
+		return true
+	case reflect.Pointer, reflect.Slice, reflect.Array:
+		return holdsSecret(typ.Elem(), seen)
+	case reflect.Map:
+		return holdsSecret(typ.Elem(), seen)
+	case reflect.Struct:
+		for _, f := range optreflect.Fields(typ, "json") {
+			if holdsSecret(f.Type, seen) {
+				return true
+			}
+		}
+	}
+	return false
+}
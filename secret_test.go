@@ -0,0 +1,250 @@
+package param_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/qntx/param"
+)
+
+type Credentials struct {
+	User     param.Opt[string]    `json:"user,omitempty"`
+	Password param.Secret[string] `json:"password,omitempty"`
+	Token    param.Secret[string] `json:"token,omitempty"`
+	OTP      param.Secret[int]    `json:"otp,omitempty"`
+}
+
+// TestSecretDecoding validates that secrets decode with the tri-state semantics of Opt.
+func TestSecretDecoding(t *testing.T) {
+	var c Credentials
+	if err := json.Unmarshal([]byte(`{"user":"alice","password":"hunter2","token":null}`), &c); err != nil {
+		t.Fatalf("Unmarshal() returned an unexpected error: %v", err)
+	}
+	if v, ok := c.Password.Get(); !ok || v != "hunter2" {
+		t.Errorf("Password.Get() got %q, %v, want %q, true", v, ok, "hunter2")
+	}
+	if !c.Token.IsNull() {
+		t.Error("Token should be null")
+	}
+	if c.OTP.IsSet() {
+		t.Error("OTP should be unset")
+	}
+	if got := c.Password.Reveal(); !param.Equal(got, param.From("hunter2")) {
+		t.Errorf("Reveal() got %v, want hunter2", got)
+	}
+}
+
+// TestSecretRedaction validates that formatted and logged secrets are redacted.
+func TestSecretRedaction(t *testing.T) {
+	c := Credentials{
+		User:     param.From("alice"),
+		Password: param.SecretOf("hunter2"),
+		Token:    param.NullSecret[string](),
+	}
+
+	testCases := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{name: "%v", got: fmt.Sprintf("%v", c.Password), want: param.RedactedMarker},
+		{name: "%q", got: fmt.Sprintf("%q", c.Password), want: param.RedactedMarker},
+		{name: "%d", got: fmt.Sprintf("%d", param.SecretOf(1234)), want: param.RedactedMarker},
+		{name: "String", got: c.Password.String(), want: param.RedactedMarker},
+		{name: "Null", got: c.Token.String(), want: param.NullToken},
+		{name: "Unset", got: c.OTP.String(), want: param.UnsetMarker},
+		{name: "%+v struct", got: fmt.Sprintf("%+v", c), want: "{User:alice Password:[REDACTED] Token:null OTP:<unset>}"},
+		{name: "GoString", got: fmt.Sprintf("%#v", c.Password), want: "param.SecretOf[string]([REDACTED])"},
+		{name: "GoString null", got: fmt.Sprintf("%#v", c.Token), want: "param.NullSecret[string]()"},
+		{name: "GoString unset", got: fmt.Sprintf("%#v", c.OTP), want: "param.Secret[int]{}"},
+		{name: "LogValue", got: c.Password.LogValue().String(), want: param.RedactedMarker},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.got != tc.want {
+				t.Errorf("got %q, want %q", tc.got, tc.want)
+			}
+		})
+	}
+
+	t.Run("LogGroup", func(t *testing.T) {
+		var buf bytes.Buffer
+		slog.New(slog.NewTextHandler(&buf, nil)).Info("login", param.LogGroup("creds", c))
+		if out := buf.String(); strings.Contains(out, "hunter2") || !strings.Contains(out, "creds.password=[REDACTED]") {
+			t.Errorf("LogGroup() logged %s, want a redacted password", out)
+		}
+	})
+}
+
+// TestSecretMarshaling validates that marshaling reveals secrets.
+func TestSecretMarshaling(t *testing.T) {
+	c := Credentials{Password: param.SecretOf("hunter2"), Token: param.NullSecret[string]()}
+
+	data, err := json.Marshal(c)
+	if err != nil {
+		t.Fatalf("Marshal() returned an unexpected error: %v", err)
+	}
+	if want := `{"password":"hunter2","token":null}`; string(data) != want {
+		t.Errorf("Marshal() got %s, want %s", data, want)
+	}
+	if text, _ := c.Password.MarshalText(); string(text) != "hunter2" {
+		t.Errorf("MarshalText() got %s, want hunter2", text)
+	}
+}
+
+type Base struct {
+	APIKey param.Secret[string] `json:"api_key"`
+}
+
+type Account struct {
+	*Base
+	Name   param.Opt[string]            `json:"name"`
+	Login  param.Opt[Credentials]       `json:"login,omitempty"`
+	Keys   []param.Secret[string]       `json:"keys,omitempty"`
+	Vault  map[string]param.Secret[int] `json:"vault,omitempty"`
+	Extra  any                          `json:"extra,omitempty"`
+	Backup *Credentials                 `json:"backup,omitempty"`
+	Plain  param.Val[int]               `json:"plain,omitzero"`
+}
+
+type upperKey string
+
+func (k upperKey) MarshalText() ([]byte, error) {
+	return []byte(strings.ToUpper(string(k))), nil
+}
+
+type textKey struct{ A, B int }
+
+func (k textKey) MarshalText() ([]byte, error) {
+	return []byte(fmt.Sprintf("%d-%d", k.A, k.B)), nil
+}
+
+// counterKey marshals to a different text on every call, so its JSON key cannot be
+// matched back to the map entry.
+type counterKey struct{ Name string }
+
+var counter int
+
+func (k counterKey) MarshalText() ([]byte, error) {
+	counter++
+	return []byte(fmt.Sprint(k.Name, counter)), nil
+}
+
+// TestMarshalRedacted validates that MarshalRedacted replaces set secrets wherever
+// they are, without affecting other marshaling.
+func TestMarshalRedacted(t *testing.T) {
+	testCases := []struct {
+		name  string
+		input any
+		want  string
+	}{
+		{
+			name:  "Struct",
+			input: Credentials{User: param.From("bob"), Password: param.SecretOf("hunter2"), Token: param.NullSecret[string]()},
+			want:  `{"user":"bob","password":"[REDACTED]","token":null}`,
+		},
+		{
+			name:  "Secret",
+			input: param.SecretOf(42),
+			want:  `"[REDACTED]"`,
+		},
+		{
+			name: "Nested secrets keep the member order",
+			input: &Account{
+				Base:   &Base{APIKey: param.SecretOf("k")},
+				Name:   param.From("acme"),
+				Login:  param.From(Credentials{Password: param.SecretOf("p"), OTP: param.SecretOf(123456)}),
+				Keys:   []param.Secret[string]{param.SecretOf("a"), param.NullSecret[string]()},
+				Vault:  map[string]param.Secret[int]{"pin": param.SecretOf(1234)},
+				Extra:  Credentials{Token: param.SecretOf("t")},
+				Backup: &Credentials{User: param.From("root")},
+				Plain:  param.ValOf(1),
+			},
+			want: `{"api_key":"[REDACTED]","name":"acme","login":{"password":"[REDACTED]","otp":"[REDACTED]"},` +
+				`"keys":["[REDACTED]",null],"vault":{"pin":"[REDACTED]"},"extra":{"token":"[REDACTED]"},` +
+				`"backup":{"user":"root"},"plain":1}`,
+		},
+		{
+			name:  "No secrets",
+			input: map[string]any{"a": []int{1}, "b": nil},
+			want:  `{"a":[1],"b":null}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := param.MarshalRedacted(tc.input)
+			if err != nil {
+				t.Fatalf("MarshalRedacted() returned an unexpected error: %v", err)
+			}
+			if string(got) != tc.want {
+				t.Errorf("MarshalRedacted() got %s, want %s", got, tc.want)
+			}
+		})
+	}
+
+	t.Run("Map keys", func(t *testing.T) {
+		// encoding/json writes string kinded TextMarshaler keys with MarshalText
+		// under json/v2 and as is otherwise, so only the values are compared.
+		for _, input := range []any{
+			map[int]param.Secret[string]{1: param.SecretOf("hunter2"), -2: param.SecretOf("hunter2")},
+			map[uint8]param.Secret[string]{7: param.SecretOf("hunter2")},
+			map[upperKey]param.Secret[string]{"a": param.SecretOf("hunter2")},
+			map[textKey]param.Secret[string]{{1, 2}: param.SecretOf("hunter2")},
+		} {
+			data, err := param.MarshalRedacted(input)
+			if err != nil {
+				t.Fatalf("MarshalRedacted(%T) returned an unexpected error: %v", input, err)
+			}
+			var got map[string]string
+			if err := json.Unmarshal(data, &got); err != nil {
+				t.Fatalf("MarshalRedacted(%T) got invalid JSON %s: %v", input, data, err)
+			}
+			for k, v := range got {
+				if v != param.RedactedMarker {
+					t.Errorf("MarshalRedacted(%T) got %q for key %q, want %s", input, v, k, param.RedactedMarker)
+				}
+			}
+		}
+	})
+
+	t.Run("Unmatched map key", func(t *testing.T) {
+		input := map[counterKey]param.Secret[string]{{"a"}: param.SecretOf("hunter2")}
+		if data, err := param.MarshalRedacted(input); err == nil {
+			t.Errorf("MarshalRedacted() got %s, want an error", data)
+		}
+	})
+
+	t.Run("Concurrent forwarding", func(t *testing.T) {
+		c := Credentials{Password: param.SecretOf("hunter2")}
+		var wg sync.WaitGroup
+		for i := 0; i < 50; i++ {
+			wg.Add(2)
+			go func() {
+				defer wg.Done()
+				if data, _ := param.MarshalRedacted(c); string(data) != `{"password":"[REDACTED]"}` {
+					t.Errorf("MarshalRedacted() got %s", data)
+				}
+			}()
+			go func() {
+				defer wg.Done()
+				if data, _ := json.Marshal(c); string(data) != `{"password":"hunter2"}` {
+					t.Errorf("json.Marshal() got %s", data)
+				}
+			}()
+		}
+		wg.Wait()
+	})
+
+	t.Run("Marshal error", func(t *testing.T) {
+		if _, err := param.MarshalRedacted(make(chan int)); err == nil {
+			t.Error("Expected an error for an unsupported type but got nil")
+		}
+	})
+}
@@ -0,0 +1,74 @@
+package param
+
+import (
+	"log/slog"
+	"reflect"
+
+	"github.com/qntx/param/internal/optreflect"
+)
+
+// UnsetMarker is the log value of an unset field.
+const UnsetMarker = "<unset>"
+
+// Ensure Opt and Val implement slog.LogValuer
+var _ slog.LogValuer = Opt[any](nil)
+var _ slog.LogValuer = Val[any]{}
+
+// LogValue implements slog.LogValuer. A value is logged as itself, null as a nil
+// value (null in slog.JSONHandler output) and unset as UnsetMarker. Use LogGroup
+// to leave unset fields out of struct logs.
+func (t Opt[T]) LogValue() slog.Value {
+	if v, ok := t.Get(); ok {
+		return slog.AnyValue(v)
+	}
+	if t.IsNull() {
+		return slog.AnyValue(nil)
+	}
+	return slog.StringValue(UnsetMarker)
+}
+
+// LogValue implements slog.LogValuer like Opt.LogValue.
+func (t Val[T]) LogValue() slog.Value {
+	return t.Opt().LogValue()
+}
+
+// LogGroup returns a group attribute holding the fields of the struct v, named by
+// their json tags, with exactly the members json.Marshal would emit: unset fields
+// are skipped, null ones are logged as nil and nested structs become nested
+// groups. Secret fields stay redacted. Values other than structs and maps are
+// logged as is.
+func LogGroup(key string, v any) slog.Attr {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	if !rv.IsValid() || !optreflect.IsObject(rv.Type()) || rv.Kind() == reflect.Map && rv.IsNil() {
+		return slog.Any(key, v)
+	}
+	return slog.Attr{Key: key, Value: slog.GroupValue(logAttrs(rv)...)}
+}
+
+func logAttrs(v reflect.Value) []slog.Attr {
+	members := optreflect.Members(v)
+	attrs := make([]slog.Attr, 0, len(members))
+	for _, m := range members {
+		switch {
+		case isSecret(m.Source):
+			attrs = append(attrs, slog.Any(m.Key, m.Source.Interface()))
+		case m.Null:
+			attrs = append(attrs, slog.Any(m.Key, nil))
+		case optreflect.IsObject(m.Value.Type()):
+			attrs = append(attrs, slog.Attr{Key: m.Key, Value: slog.GroupValue(logAttrs(m.Value)...)})
+		default:
+			attrs = append(attrs, slog.Any(m.Key, m.Value.Interface()))
+		}
+	}
+	return attrs
+}
+
+// isSecret reports whether v holds a Secret, which must be logged through its own
+// LogValue so the value stays redacted.
+func isSecret(v reflect.Value) bool {
+	_, ok := v.Interface().(interface{ secret() })
+	return ok
+}
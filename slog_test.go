@@ -0,0 +1,73 @@
+package param_test
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/qntx/param"
+)
+
+// TestLogValue validates the log value of each state.
+func TestLogValue(t *testing.T) {
+	testCases := []struct {
+		name  string
+		input slog.LogValuer
+		want  any
+	}{
+		{name: "Value", input: param.From("Alice"), want: "Alice"},
+		{name: "Null", input: param.Null[string](), want: nil},
+		{name: "Unset", input: param.Zero[string](), want: param.UnsetMarker},
+		{name: "Val", input: param.ValOf(42), want: int64(42)},
+		{name: "Val unset", input: param.Val[int]{}, want: param.UnsetMarker},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.input.LogValue().Any(); got != tc.want {
+				t.Errorf("LogValue() got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+// TestLogGroup validates that a logged patch shows exactly the sent fields.
+func TestLogGroup(t *testing.T) {
+	type Address struct {
+		City param.Opt[string] `json:"city"`
+		Zip  param.Opt[string] `json:"zip"`
+	}
+	type UserPatch struct {
+		Name    param.Opt[string]  `json:"name"`
+		Email   param.Opt[string]  `json:"email"`
+		Age     param.Opt[int]     `json:"age"`
+		Address param.Opt[Address] `json:"address"`
+		Note    *string            `json:"note"`
+	}
+
+	patch := UserPatch{
+		Name:    param.From("Alice"),
+		Email:   param.Null[string](),
+		Address: param.From(Address{City: param.From("Paris")}),
+	}
+
+	var buf bytes.Buffer
+	slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{
+		ReplaceAttr: func(_ []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+			return a
+		},
+	})).Info("patch", param.LogGroup("user", &patch), slog.Any("age", patch.Age))
+
+	want := `{"level":"INFO","msg":"patch","user":{"name":"Alice","email":null,"address":{"city":"Paris"}},"age":"<unset>"}`
+	if got := strings.TrimSpace(buf.String()); got != want {
+		t.Errorf("LogGroup() logged %s, want %s", got, want)
+	}
+
+	if a := param.LogGroup("n", 5); a.Value.Int64() != 5 {
+		t.Errorf("LogGroup() of a non-struct got %v, want 5", a.Value)
+	}
+}
@@ -0,0 +1,65 @@
+package param
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"reflect"
+)
+
+// ErrUnset is returned by Opt.Value when the field is unset, so callers can tell
+// "don't write this column" apart from "write NULL".
+var ErrUnset = errors.New("param: value is unset")
+
+// Ensure Opt implements sql.Scanner and driver.Valuer
+var _ sql.Scanner = (*Opt[any])(nil)
+var _ driver.Valuer = (*Opt[any])(nil)
+
+// Scan implements sql.Scanner. A NULL column scans into the null state, any other
+// value is converted to T following the database/sql conversion rules.
+func (t *Opt[T]) Scan(src any) error {
+	if src == nil {
+		t.SetNull()
+		return nil
+	}
+	var n sql.Null[T]
+	if err := n.Scan(src); err != nil {
+		return err
+	}
+	t.Set(n.V)
+	return nil
+}
+
+// Value implements driver.Valuer. A null field is written as NULL, while an unset
+// field returns ErrUnset since it should not be written at all.
+func (t Opt[T]) Value() (driver.Value, error) {
+	if t.IsNull() {
+		return nil, nil
+	}
+	v, ok := t.Get()
+	if !ok {
+		return nil, ErrUnset
+	}
+	return value(v)
+}
+
+// value converts v into a driver.Value like sql.Null[T].Value does from Go 1.24:
+// a driver.Valuer is asked for its own value first, then the result is converted
+// with driver.DefaultParameterConverter, so that e.g. an int becomes an int64.
+func value(v any) (driver.Value, error) {
+	if valuer, ok := v.(driver.Valuer); ok && !nilPointer(v) {
+		dv, err := valuer.Value()
+		if err != nil {
+			return nil, err
+		}
+		v = dv
+	}
+	return driver.DefaultParameterConverter.ConvertValue(v)
+}
+
+// nilPointer reports whether v is a nil pointer, which DefaultParameterConverter
+// handles itself so that Value methods on value receivers are not called on it.
+func nilPointer(v any) bool {
+	rv := reflect.ValueOf(v)
+	return rv.Kind() == reflect.Pointer && rv.IsNil()
+}
@@ -0,0 +1,117 @@
+package param_test
+
+import (
+	"database/sql/driver"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/qntx/param"
+)
+
+// TestScan validates conversion of driver values into Opt.
+func TestScan(t *testing.T) {
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	t.Run("NULL scans into null", func(t *testing.T) {
+		n := param.From(7)
+		if err := n.Scan(nil); err != nil {
+			t.Fatalf("Scan() failed: %v", err)
+		}
+		if !n.IsNull() {
+			t.Error("Expected IsNull to be true after scanning NULL")
+		}
+	})
+
+	t.Run("int64 into Opt[int]", func(t *testing.T) {
+		var n param.Opt[int]
+		if err := n.Scan(int64(42)); err != nil {
+			t.Fatalf("Scan() failed: %v", err)
+		}
+		if v, ok := n.Get(); !ok || v != 42 {
+			t.Errorf("Get() got (%d, %v), want (42, true)", v, ok)
+		}
+	})
+
+	t.Run("[]byte into Opt[string]", func(t *testing.T) {
+		var n param.Opt[string]
+		if err := n.Scan([]byte("hello")); err != nil {
+			t.Fatalf("Scan() failed: %v", err)
+		}
+		if v, ok := n.Get(); !ok || v != "hello" {
+			t.Errorf("Get() got (%q, %v), want (\"hello\", true)", v, ok)
+		}
+	})
+
+	t.Run("string into Opt[float64]", func(t *testing.T) {
+		var n param.Opt[float64]
+		if err := n.Scan("1.5"); err != nil {
+			t.Fatalf("Scan() failed: %v", err)
+		}
+		if v, ok := n.Get(); !ok || v != 1.5 {
+			t.Errorf("Get() got (%v, %v), want (1.5, true)", v, ok)
+		}
+	})
+
+	t.Run("time.Time into Opt[time.Time]", func(t *testing.T) {
+		var n param.Opt[time.Time]
+		if err := n.Scan(now); err != nil {
+			t.Fatalf("Scan() failed: %v", err)
+		}
+		if v, ok := n.Get(); !ok || !v.Equal(now) {
+			t.Errorf("Get() got (%v, %v), want (%v, true)", v, ok, now)
+		}
+	})
+
+	t.Run("Conversion error leaves Opt untouched", func(t *testing.T) {
+		n := param.From(1)
+		if err := n.Scan("not a number"); err == nil {
+			t.Fatal("Expected a conversion error but got nil")
+		}
+		if v, ok := n.Get(); !ok || v != 1 {
+			t.Errorf("Get() got (%d, %v), want (1, true)", v, ok)
+		}
+	})
+}
+
+type level int
+
+// cents is a driver.Valuer returning a plain int, which must still be converted.
+type cents int
+
+func (c cents) Value() (driver.Value, error) {
+	return int(c), nil
+}
+
+// TestValue validates conversion of Opt into driver values.
+func TestValue(t *testing.T) {
+	testCases := []struct {
+		name    string
+		n       driver.Valuer
+		want    driver.Value
+		wantErr error
+	}{
+		{name: "Unset", n: param.Zero[int](), wantErr: param.ErrUnset},
+		{name: "Unset (nil map)", n: param.Opt[string](nil), wantErr: param.ErrUnset},
+		{name: "Null", n: param.Null[int](), want: nil},
+		{name: "Int is widened to int64", n: param.From(42), want: int64(42)},
+		{name: "String", n: param.From("hello"), want: "hello"},
+		{name: "Zero value is written", n: param.From(false), want: false},
+		{name: "Named type is converted", n: param.From(level(3)), want: int64(3)},
+		{name: "Valuer result is converted", n: param.From(cents(150)), want: int64(150)},
+		{name: "Nil Valuer pointer", n: param.From[*cents](nil), want: nil},
+		{name: "Val int is widened to int64", n: param.ValOf(int32(7)), want: int64(7)},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := tc.n.Value()
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("Value() error got %v, want %v", err, tc.wantErr)
+			}
+			if got != tc.want {
+				t.Errorf("Value() got %#v, want %#v", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,113 @@
+// Package sqlupdate builds the SET clause of an SQL UPDATE statement from a struct
+// of param.Opt fields, touching only the fields that were set.
+package sqlupdate
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/qntx/param/internal/optreflect"
+)
+
+// ErrNoChanges is returned when the patch has no set fields, since an UPDATE with
+// an empty SET clause is not valid SQL.
+var ErrNoChanges = errors.New("sqlupdate: no fields to update")
+
+// Placeholder selects how bind parameters are written.
+type Placeholder int
+
+const (
+	// Dollar writes positional parameters as $1, $2, ... (PostgreSQL).
+	Dollar Placeholder = iota
+	// Question writes positional parameters as ? (MySQL, SQLite).
+	Question
+	// Named writes parameters as :name and binds them with sql.Named.
+	Named
+)
+
+// Builder configures how the SET clause is generated.
+type Builder struct {
+	// Placeholder is the bind parameter style.
+	Placeholder Placeholder
+	// Start is the index of the first Dollar parameter, so the SET clause can
+	// follow parameters already used by the statement. Defaults to 1.
+	Start int
+}
+
+// Build is shorthand for Builder{Placeholder: style}.Build(patch).
+func Build(patch any, style Placeholder) (string, []any, error) {
+	return Builder{Placeholder: style}.Build(patch)
+}
+
+// Build returns the SET clause for patch, e.g. "SET name = $1, bio = $2", along
+// with its arguments. patch must be a struct or a pointer to one.
+//
+// Column names come from the `db` tag, then the `json` tag, then the field name.
+// Fields tagged "-", fields that are not Opt and unset Opt fields are skipped,
+// null fields bind NULL, and embedded structs are flattened.
+func (b Builder) Build(patch any) (string, []any, error) {
+	v := reflect.ValueOf(patch)
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return "", nil, fmt.Errorf("sqlupdate: nil %s", v.Type())
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return "", nil, fmt.Errorf("sqlupdate: expected struct, got %s", v.Type())
+	}
+
+	addr := optreflect.Addressable(v)
+
+	next := b.Start
+	if next == 0 {
+		next = 1
+	}
+
+	var (
+		sets []string
+		args []any
+	)
+	walk(addr, func(col string, val any) {
+		var ph string
+		switch b.Placeholder {
+		case Question:
+			ph = "?"
+			args = append(args, val)
+		case Named:
+			ph = ":" + col
+			args = append(args, sql.Named(col, val))
+		default:
+			ph = "$" + strconv.Itoa(next)
+			next++
+			args = append(args, val)
+		}
+		sets = append(sets, col+" = "+ph)
+	})
+	if len(sets) == 0 {
+		return "", nil, ErrNoChanges
+	}
+	return "SET " + strings.Join(sets, ", "), args, nil
+}
+
+// walk calls fn for every set Opt field of the struct v, in declaration order.
+func walk(v reflect.Value, fn func(col string, val any)) {
+	for _, f := range optreflect.Fields(v.Type(), "db", "json") {
+		if !optreflect.Is(f.Type) {
+			continue
+		}
+		fv := v.FieldByIndex(f.Index)
+		opt := optreflect.State(fv)
+		switch {
+		case opt.IsNull():
+			fn(f.Key, nil)
+		case opt.IsSet():
+			val, _ := optreflect.Get(fv)
+			fn(f.Key, val.Interface())
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package sqlupdate_test
+
+import (
+	"database/sql"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/qntx/param"
+	"github.com/qntx/param/sqlupdate"
+)
+
+type Audit struct {
+	UpdatedBy param.Opt[string] `db:"updated_by"`
+}
+
+type UserPatch struct {
+	ID    int               `db:"id"`
+	Name  param.Opt[string] `db:"name" json:"full_name"`
+	Age   param.Opt[int]    `json:"age,omitempty"`
+	Bio   param.Opt[string]
+	Email param.Opt[string] `db:"-"`
+	Audit
+}
+
+// TestBuild validates SET clause generation for each placeholder style.
+func TestBuild(t *testing.T) {
+	patch := UserPatch{
+		ID:    1,
+		Name:  param.From("Alice"),
+		Bio:   param.Null[string](),
+		Email: param.From("ignored@example.com"),
+		Audit: Audit{UpdatedBy: param.From("admin")},
+	}
+
+	testCases := []struct {
+		name     string
+		builder  sqlupdate.Builder
+		wantSQL  string
+		wantArgs []any
+	}{
+		{
+			name:     "Dollar",
+			builder:  sqlupdate.Builder{Placeholder: sqlupdate.Dollar},
+			wantSQL:  "SET name = $1, Bio = $2, updated_by = $3",
+			wantArgs: []any{"Alice", nil, "admin"},
+		},
+		{
+			name:     "Dollar with offset",
+			builder:  sqlupdate.Builder{Placeholder: sqlupdate.Dollar, Start: 3},
+			wantSQL:  "SET name = $3, Bio = $4, updated_by = $5",
+			wantArgs: []any{"Alice", nil, "admin"},
+		},
+		{
+			name:     "Question",
+			builder:  sqlupdate.Builder{Placeholder: sqlupdate.Question},
+			wantSQL:  "SET name = ?, Bio = ?, updated_by = ?",
+			wantArgs: []any{"Alice", nil, "admin"},
+		},
+		{
+			name:    "Named",
+			builder: sqlupdate.Builder{Placeholder: sqlupdate.Named},
+			wantSQL: "SET name = :name, Bio = :Bio, updated_by = :updated_by",
+			wantArgs: []any{
+				sql.Named("name", "Alice"),
+				sql.Named("Bio", nil),
+				sql.Named("updated_by", "admin"),
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			gotSQL, gotArgs, err := tc.builder.Build(&patch)
+			if err != nil {
+				t.Fatalf("Build() returned an unexpected error: %v", err)
+			}
+			if gotSQL != tc.wantSQL {
+				t.Errorf("Build() SQL got %q, want %q", gotSQL, tc.wantSQL)
+			}
+			if !reflect.DeepEqual(gotArgs, tc.wantArgs) {
+				t.Errorf("Build() args got %#v, want %#v", gotArgs, tc.wantArgs)
+			}
+		})
+	}
+}
+
+// TestBuildJSONTag validates the fallback to the json tag.
+func TestBuildJSONTag(t *testing.T) {
+	gotSQL, gotArgs, err := sqlupdate.Build(UserPatch{Age: param.From(30)}, sqlupdate.Question)
+	if err != nil {
+		t.Fatalf("Build() returned an unexpected error: %v", err)
+	}
+	if want := "SET age = ?"; gotSQL != want {
+		t.Errorf("Build() SQL got %q, want %q", gotSQL, want)
+	}
+	if want := []any{30}; !reflect.DeepEqual(gotArgs, want) {
+		t.Errorf("Build() args got %#v, want %#v", gotArgs, want)
+	}
+}
+
+// TestBuildErrors validates the error cases.
+func TestBuildErrors(t *testing.T) {
+	t.Run("No set fields", func(t *testing.T) {
+		_, _, err := sqlupdate.Build(UserPatch{ID: 1}, sqlupdate.Dollar)
+		if !errors.Is(err, sqlupdate.ErrNoChanges) {
+			t.Errorf("Build() error got %v, want %v", err, sqlupdate.ErrNoChanges)
+		}
+	})
+
+	t.Run("Not a struct", func(t *testing.T) {
+		if _, _, err := sqlupdate.Build(42, sqlupdate.Dollar); err == nil {
+			t.Error("Expected an error for a non-struct patch but got nil")
+		}
+	})
+
+	t.Run("Nil pointer", func(t *testing.T) {
+		if _, _, err := sqlupdate.Build((*UserPatch)(nil), sqlupdate.Dollar); err == nil {
+			t.Error("Expected an error for a nil patch but got nil")
+		}
+	})
+}
@@ -0,0 +1,104 @@
+package param
+
+import (
+	"encoding"
+
+	"github.com/qntx/param/internal/textconv"
+)
+
+// NullToken is the text representation of an explicit `null` used by MarshalText
+// and UnmarshalText. Use MarshalTextNull and UnmarshalTextNull to choose another one.
+const NullToken = "null"
+
+// TextOpt defines the interface for types that can represent nullability states as
+// text with a configurable null token.
+type TextOpt interface {
+	JSONOpt
+	MarshalTextNull(null string) ([]byte, error)
+	UnmarshalTextNull(text []byte, null string) error
+}
+
+// Ensure Opt and Val implement TextOpt, encoding.TextMarshaler and encoding.TextUnmarshaler
+var _ TextOpt = (*Opt[any])(nil)
+var _ encoding.TextMarshaler = (*Opt[any])(nil)
+var _ encoding.TextUnmarshaler = (*Opt[any])(nil)
+var _ TextOpt = (*Val[any])(nil)
+var _ encoding.TextMarshaler = (*Val[any])(nil)
+var _ encoding.TextUnmarshaler = (*Val[any])(nil)
+
+// MarshalText implements encoding.TextMarshaler, writing NullToken for a null field.
+func (t Opt[T]) MarshalText() ([]byte, error) {
+	return t.MarshalTextNull(NullToken)
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, reading NullToken as `null`.
+func (t *Opt[T]) UnmarshalText(text []byte) error {
+	return t.UnmarshalTextNull(text, NullToken)
+}
+
+// MarshalTextNull formats the field as text, writing null for a null field. Values
+// are formatted with T's encoding.TextMarshaler implementation, strconv for
+// builtin kinds, or like "1m30s" for time.Duration; an unset field is formatted as
+// the zero value of T.
+func (t Opt[T]) MarshalTextNull(null string) ([]byte, error) {
+	if t.IsNull() {
+		return []byte(null), nil
+	}
+	s, err := textconv.Format(t[true])
+	if err != nil {
+		return nil, err
+	}
+	return []byte(s), nil
+}
+
+// UnmarshalTextNull parses text into the field, which becomes null if text equals
+// null. Other values are parsed with T's encoding.TextUnmarshaler implementation,
+// strconv for builtin kinds, or time.ParseDuration for time.Duration.
+func (t *Opt[T]) UnmarshalTextNull(text []byte, null string) error {
+	if string(text) == null {
+		t.SetNull()
+		return nil
+	}
+	var v T
+	if err := textconv.Parse(string(text), &v); err != nil {
+		return err
+	}
+	t.Set(v)
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler, following the same rules as Opt.MarshalText.
+func (t Val[T]) MarshalText() ([]byte, error) {
+	return t.MarshalTextNull(NullToken)
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, following the same rules as Opt.UnmarshalText.
+func (t *Val[T]) UnmarshalText(text []byte) error {
+	return t.UnmarshalTextNull(text, NullToken)
+}
+
+// MarshalTextNull formats the field as text, following the same rules as Opt.MarshalTextNull.
+func (t Val[T]) MarshalTextNull(null string) ([]byte, error) {
+	if t.state == stateNull {
+		return []byte(null), nil
+	}
+	s, err := textconv.Format(t.v)
+	if err != nil {
+		return nil, err
+	}
+	return []byte(s), nil
+}
+
+// UnmarshalTextNull parses text into the field, following the same rules as Opt.UnmarshalTextNull.
+func (t *Val[T]) UnmarshalTextNull(text []byte, null string) error {
+	if string(text) == null {
+		t.SetNull()
+		return nil
+	}
+	var v T
+	if err := textconv.Parse(string(text), &v); err != nil {
+		return err
+	}
+	t.Set(v)
+	return nil
+}
@@ -0,0 +1,142 @@
+package param_test
+
+import (
+	"encoding"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/qntx/param"
+)
+
+// TestTextMarshaling validates the text serialization logic.
+func TestTextMarshaling(t *testing.T) {
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	testCases := []struct {
+		name  string
+		input encoding.TextMarshaler
+		want  string
+	}{
+		{name: "Int", input: param.From(42), want: "42"},
+		{name: "Float", input: param.From(1.5), want: "1.5"},
+		{name: "Bool", input: param.From(true), want: "true"},
+		{name: "String", input: param.From("hello"), want: "hello"},
+		{name: "Time via TextMarshaler", input: param.From(now), want: "2024-01-02T03:04:05Z"},
+		{name: "Addr via TextMarshaler", input: param.From(netip.MustParseAddr("10.0.0.1")), want: "10.0.0.1"},
+		{name: "Duration", input: param.From(90 * time.Second), want: "1m30s"},
+		{name: "Null", input: param.Null[int](), want: param.NullToken},
+		{name: "Unset (marshals to zero value)", input: param.Zero[int](), want: "0"},
+		{name: "Val", input: param.ValOf(uint8(7)), want: "7"},
+		{name: "Val null", input: param.NullVal[uint8](), want: param.NullToken},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := tc.input.MarshalText()
+			if err != nil {
+				t.Fatalf("MarshalText() returned an unexpected error: %v", err)
+			}
+			if string(got) != tc.want {
+				t.Errorf("MarshalText() got %q, want %q", got, tc.want)
+			}
+		})
+	}
+
+	t.Run("Unsupported type error", func(t *testing.T) {
+		if _, err := param.From([]int{1}).MarshalText(); err == nil {
+			t.Fatal("Expected an unsupported type error but got nil")
+		}
+	})
+}
+
+// TestTextUnmarshaling validates the text deserialization logic.
+func TestTextUnmarshaling(t *testing.T) {
+	t.Run("Builtin kinds", func(t *testing.T) {
+		var i param.Opt[int]
+		var f param.Opt[float32]
+		var s param.Opt[string]
+		if err := i.UnmarshalText([]byte("-3")); err != nil {
+			t.Fatalf("UnmarshalText() failed: %v", err)
+		}
+		if err := f.UnmarshalText([]byte("2.5")); err != nil {
+			t.Fatalf("UnmarshalText() failed: %v", err)
+		}
+		if err := s.UnmarshalText([]byte("")); err != nil {
+			t.Fatalf("UnmarshalText() failed: %v", err)
+		}
+		if i.MustGet() != -3 || f.MustGet() != 2.5 || s.MustGet() != "" {
+			t.Error("Did not unmarshal valid values correctly")
+		}
+	})
+
+	t.Run("TextUnmarshaler kinds", func(t *testing.T) {
+		var tm param.Opt[time.Time]
+		var addr param.Val[netip.Addr]
+		if err := tm.UnmarshalText([]byte("2024-01-02T03:04:05Z")); err != nil {
+			t.Fatalf("UnmarshalText() failed: %v", err)
+		}
+		if err := addr.UnmarshalText([]byte("::1")); err != nil {
+			t.Fatalf("UnmarshalText() failed: %v", err)
+		}
+		if tm.MustGet().Year() != 2024 || addr.MustGet() != netip.IPv6Loopback() {
+			t.Error("Did not unmarshal valid values correctly")
+		}
+	})
+
+	t.Run("Duration", func(t *testing.T) {
+		var d param.Opt[time.Duration]
+		if err := d.UnmarshalText([]byte("1m30s")); err != nil {
+			t.Fatalf("UnmarshalText() failed: %v", err)
+		}
+		if d.MustGet() != 90*time.Second {
+			t.Errorf("Get() got %v, want 1m30s", d.MustGet())
+		}
+		if err := d.UnmarshalText([]byte("90000000000")); err == nil {
+			t.Error("Expected an error for a duration without unit but got nil")
+		}
+	})
+
+	t.Run("Null token", func(t *testing.T) {
+		var o param.Opt[int]
+		var v param.Val[int]
+		if err := o.UnmarshalText([]byte(param.NullToken)); err != nil {
+			t.Fatalf("UnmarshalText() failed: %v", err)
+		}
+		if err := v.UnmarshalText([]byte(param.NullToken)); err != nil {
+			t.Fatalf("UnmarshalText() failed: %v", err)
+		}
+		if !o.IsNull() || !v.IsNull() {
+			t.Error("Fields should be Null after unmarshaling the null token")
+		}
+	})
+
+	t.Run("Custom null token", func(t *testing.T) {
+		var s param.Opt[string]
+		if err := s.UnmarshalTextNull([]byte("null"), "NULL"); err != nil {
+			t.Fatalf("UnmarshalTextNull() failed: %v", err)
+		}
+		if s.MustGet() != "null" {
+			t.Errorf("Get() got %q, want \"null\"", s.MustGet())
+		}
+		if err := s.UnmarshalTextNull([]byte("NULL"), "NULL"); err != nil {
+			t.Fatalf("UnmarshalTextNull() failed: %v", err)
+		}
+		if !s.IsNull() {
+			t.Error("Field should be Null after unmarshaling the custom null token")
+		}
+		if got, _ := s.MarshalTextNull("NULL"); string(got) != "NULL" {
+			t.Errorf("MarshalTextNull() got %q, want \"NULL\"", got)
+		}
+	})
+
+	t.Run("Parse error", func(t *testing.T) {
+		o := param.From(1)
+		if err := o.UnmarshalText([]byte("abc")); err == nil {
+			t.Fatal("Expected a parse error but got nil")
+		}
+		if o.MustGet() != 1 {
+			t.Error("A failed parse should leave the field untouched")
+		}
+	})
+}
@@ -0,0 +1,165 @@
+package param
+
+import (
+	"bytes"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+)
+
+// Val is an alternative to Opt with the same three states, backed by a struct
+// instead of a map. The zero Val is unset. Unmarshaling allocates far less than
+// with Opt, but marshaling allocates more, since encoding/json copies each Val to
+// the heap to call its MarshalJSON.
+//
+// Since encoding/json never omits structs under `omitempty`, unset Val fields are
+// omitted with the `omitzero` tag (Go 1.24+) instead.
+type Val[T any] struct {
+	state state
+	v     T
+}
+
+// state is the tri-state of a Val.
+type state uint8
+
+const (
+	stateUnset state = iota
+	stateNull
+	stateValid
+)
+
+// Ensure Val implements JSONOpt, json.Marshaler, json.Unmarshaler, sql.Scanner and driver.Valuer
+var _ JSONOpt = (*Val[any])(nil)
+var _ json.Marshaler = (*Val[any])(nil)
+var _ json.Unmarshaler = (*Val[any])(nil)
+var _ sql.Scanner = (*Val[any])(nil)
+var _ driver.Valuer = (*Val[any])(nil)
+
+// ValOf constructs a Val[T] with the given value, representing a field explicitly set in a JSON request.
+func ValOf[T any](value T) Val[T] {
+	return Val[T]{state: stateValid, v: value}
+}
+
+// NullVal constructs a Val[T] with an explicit `null`, representing a field set to `null` in a JSON request.
+func NullVal[T any]() Val[T] {
+	return Val[T]{state: stateNull}
+}
+
+// Get retrieves the underlying value, if present, and returns an empty value and `false` if not present.
+func (t Val[T]) Get() (T, bool) {
+	if t.state != stateValid {
+		var empty T
+		return empty, false
+	}
+	return t.v, true
+}
+
+// MustGet retrieves the underlying value, if present, and panics if not present.
+func (t Val[T]) MustGet() T {
+	v, ok := t.Get()
+	if !ok {
+		panic("value is not set or null")
+	}
+	return v
+}
+
+// Set sets the underlying value to a given value.
+func (t *Val[T]) Set(value T) {
+	*t = Val[T]{state: stateValid, v: value}
+}
+
+// IsNull indicates whether the field was sent and had a value of `null`.
+func (t Val[T]) IsNull() bool {
+	return t.state == stateNull
+}
+
+// SetNull sets the field to an explicit `null`.
+func (t *Val[T]) SetNull() {
+	*t = Val[T]{state: stateNull}
+}
+
+// IsSet indicates whether the field was sent (either as null or a value).
+func (t Val[T]) IsSet() bool {
+	return t.state != stateUnset
+}
+
+// Reset clears the field, making it unset.
+func (t *Val[T]) Reset() {
+	*t = Val[T]{}
+}
+
+// IsZero indicates whether the field is unset, so it is omitted by `omitzero`.
+func (t Val[T]) IsZero() bool {
+	return t.state == stateUnset
+}
+
+// Opt converts t to the equivalent Opt.
+func (t Val[T]) Opt() Opt[T] {
+	switch t.state {
+	case stateNull:
+		return Null[T]()
+	case stateValid:
+		return From(t.v)
+	}
+	return Zero[T]()
+}
+
+// Val converts t to the equivalent Val.
+func (t Opt[T]) Val() Val[T] {
+	if t.IsNull() {
+		return NullVal[T]()
+	}
+	if v, ok := t.Get(); ok {
+		return ValOf(v)
+	}
+	return Val[T]{}
+}
+
+func (t Val[T]) MarshalJSON() ([]byte, error) {
+	if t.state == stateNull {
+		return []byte("null"), nil
+	}
+
+	// if field was unspecified, and `omitzero` is set on the field's tags, `json.Marshal` will omit this field
+
+	// otherwise: we have a value, so marshal it
+	return json.Marshal(t.v)
+}
+
+func (t *Val[T]) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		t.SetNull()
+		return nil
+	}
+	var v T
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	t.Set(v)
+	return nil
+}
+
+// Scan implements sql.Scanner, following the same rules as Opt.Scan.
+func (t *Val[T]) Scan(src any) error {
+	if src == nil {
+		t.SetNull()
+		return nil
+	}
+	var n sql.Null[T]
+	if err := n.Scan(src); err != nil {
+		return err
+	}
+	t.Set(n.V)
+	return nil
+}
+
+// Value implements driver.Valuer, following the same rules as Opt.Value.
+func (t Val[T]) Value() (driver.Value, error) {
+	switch t.state {
+	case stateNull:
+		return nil, nil
+	case stateUnset:
+		return nil, ErrUnset
+	}
+	return value(t.v)
+}
@@ -0,0 +1,184 @@
+package param_test
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/qntx/param"
+)
+
+// TestValStates validates the state methods of Val across all states.
+func TestValStates(t *testing.T) {
+	testCases := []struct {
+		name   string
+		n      param.Val[string]
+		isSet  bool
+		isNull bool
+		value  string
+	}{
+		{name: "Unset (zero value)", n: param.Val[string]{}},
+		{name: "Null", n: param.NullVal[string](), isSet: true, isNull: true},
+		{name: "Valid", n: param.ValOf("value"), isSet: true, value: "value"},
+		{name: "Valid zero value", n: param.ValOf(""), isSet: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.n.IsSet(); got != tc.isSet {
+				t.Errorf("IsSet() got %v, want %v", got, tc.isSet)
+			}
+			if got := tc.n.IsNull(); got != tc.isNull {
+				t.Errorf("IsNull() got %v, want %v", got, tc.isNull)
+			}
+			if got := tc.n.IsZero(); got != !tc.isSet {
+				t.Errorf("IsZero() got %v, want %v", got, !tc.isSet)
+			}
+			val, ok := tc.n.Get()
+			if want := tc.isSet && !tc.isNull; ok != want || val != tc.value {
+				t.Errorf("Get() got (%q, %v), want (%q, %v)", val, ok, tc.value, want)
+			}
+		})
+	}
+}
+
+// TestValSetters validates the Set, SetNull and Reset methods of Val.
+func TestValSetters(t *testing.T) {
+	var n param.Val[int]
+	n.Set(42)
+	if v, ok := n.Get(); !ok || v != 42 {
+		t.Errorf("Get() after Set() got (%d, %v), want (42, true)", v, ok)
+	}
+	n.SetNull()
+	if !n.IsNull() {
+		t.Error("SetNull() failed to make value null")
+	}
+	n.Reset()
+	if n.IsSet() {
+		t.Error("Reset() failed to make value unset")
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("MustGet should have panicked but did not")
+		}
+	}()
+	_ = n.MustGet()
+}
+
+// TestValConversion validates conversion between Val and Opt.
+func TestValConversion(t *testing.T) {
+	testCases := map[string]param.Opt[int]{
+		"Unset": param.Zero[int](),
+		"Null":  param.Null[int](),
+		"Valid": param.From(7),
+	}
+
+	for name, o := range testCases {
+		t.Run(name, func(t *testing.T) {
+			v := o.Val()
+			if v.IsSet() != o.IsSet() || v.IsNull() != o.IsNull() || !sameValue(v, o) {
+				t.Errorf("Opt.Val() got %+v, want the state of %v", v, o)
+			}
+			back := v.Opt()
+			if back.IsSet() != o.IsSet() || back.IsNull() != o.IsNull() {
+				t.Errorf("Val.Opt() got %v, want %v", back, o)
+			}
+		})
+	}
+}
+
+// TestValJSON validates the JSON round trip of Val.
+func TestValJSON(t *testing.T) {
+	type Payload struct {
+		Required param.Val[string] `json:"required"`
+		Optional param.Val[int]    `json:"optional"`
+	}
+
+	t.Run("Marshal", func(t *testing.T) {
+		got, err := json.Marshal(Payload{Required: param.ValOf("hello"), Optional: param.NullVal[int]()})
+		if err != nil {
+			t.Fatalf("json.Marshal() returned an unexpected error: %v", err)
+		}
+		assertJSONEquals(t, got, []byte(`{"required":"hello","optional":null}`))
+	})
+
+	t.Run("Unmarshal", func(t *testing.T) {
+		var p Payload
+		if err := json.Unmarshal([]byte(`{"optional":null}`), &p); err != nil {
+			t.Fatalf("json.Unmarshal() failed: %v", err)
+		}
+		if p.Required.IsSet() {
+			t.Error("A missing field should be unset")
+		}
+		if !p.Optional.IsNull() {
+			t.Error("Field should be Null after unmarshaling JSON null")
+		}
+
+		if err := json.Unmarshal([]byte(`{"required":"world","optional":0}`), &p); err != nil {
+			t.Fatalf("json.Unmarshal() failed: %v", err)
+		}
+		if p.Required.MustGet() != "world" || p.Optional.MustGet() != 0 {
+			t.Error("Did not unmarshal valid values correctly")
+		}
+	})
+
+	t.Run("Type mismatch error", func(t *testing.T) {
+		var p Payload
+		if err := json.Unmarshal([]byte(`{"required":123}`), &p); err == nil {
+			t.Fatal("Expected a type mismatch error but got nil")
+		}
+	})
+
+	t.Run("Unmarshal replaces the previous value", func(t *testing.T) {
+		type point struct {
+			X int `json:"x"`
+			Y int `json:"y"`
+		}
+
+		m := param.ValOf(map[string]int{"a": 1})
+		mo := param.From(map[string]int{"a": 1})
+		if err := json.Unmarshal([]byte(`{"b":2}`), &m); err != nil {
+			t.Fatalf("json.Unmarshal() failed: %v", err)
+		}
+		if err := json.Unmarshal([]byte(`{"b":2}`), &mo); err != nil {
+			t.Fatalf("json.Unmarshal() failed: %v", err)
+		}
+		if want := map[string]int{"b": 2}; !reflect.DeepEqual(m.MustGet(), want) || !reflect.DeepEqual(mo.MustGet(), want) {
+			t.Errorf("json.Unmarshal() got Val %v and Opt %v, want both %v", m, mo, want)
+		}
+
+		p := param.ValOf(point{X: 1, Y: 1})
+		if err := json.Unmarshal([]byte(`{"y":2}`), &p); err != nil {
+			t.Fatalf("json.Unmarshal() failed: %v", err)
+		}
+		if got := p.MustGet(); got != (point{Y: 2}) {
+			t.Errorf("json.Unmarshal() got %+v, want %+v", got, point{Y: 2})
+		}
+	})
+}
+
+// TestValSQL validates the database/sql support of Val.
+func TestValSQL(t *testing.T) {
+	var n param.Val[int]
+	if _, err := n.Value(); !errors.Is(err, param.ErrUnset) {
+		t.Errorf("Value() on unset got error %v, want %v", err, param.ErrUnset)
+	}
+	if err := n.Scan(int64(5)); err != nil {
+		t.Fatalf("Scan() failed: %v", err)
+	}
+	if got, err := n.Value(); err != nil || got != driver.Value(int64(5)) {
+		t.Errorf("Value() got (%v, %v), want (5, nil)", got, err)
+	}
+	if err := n.Scan(nil); err != nil || !n.IsNull() {
+		t.Errorf("Scan(nil) got (null=%v, %v), want (null=true, nil)", n.IsNull(), err)
+	}
+}
+
+func sameValue(v param.Val[int], o param.Opt[int]) bool {
+	a, aok := v.Get()
+	b, bok := o.Get()
+	return a == b && aok == bok
+}
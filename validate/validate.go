@@ -0,0 +1,196 @@
+// Package validate checks structs of param.Opt fields against per-state rules read
+// from `param` struct tags.
+//
+// The supported rules are:
+//
+//	required  the field must be set, either as null or a value
+//	nonnull   the field must not be null (it may still be omitted)
+//	min=N     if the field has a value, it must be at least N, or have at least N
+//	          elements or characters for strings, slices and maps
+//	max=N     like min, as an upper bound
+//
+// For example, `param:"required,nonnull,min=1,max=100"` accepts only a non-null
+// value between 1 and 100, while `param:"nonnull"` also accepts an omitted field.
+package validate
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/qntx/param/internal/optreflect"
+	"github.com/qntx/param/internal/paramtag"
+)
+
+// FieldError describes a field failing a rule.
+type FieldError struct {
+	// Path is the dotted path of the field, built from `json` names, e.g.
+	// "address.city" or "items[1].name".
+	Path string
+	// Rule is the failing rule, e.g. "required" or "max".
+	Rule string
+	// Message describes the failure.
+	Message string
+}
+
+func (e FieldError) Error() string {
+	return e.Path + ": " + e.Message
+}
+
+// Errors lists every field failing validation.
+type Errors []FieldError
+
+func (e Errors) Error() string {
+	msgs := make([]string, len(e))
+	for i, fe := range e {
+		msgs[i] = fe.Error()
+	}
+	return "validate: " + strings.Join(msgs, "; ")
+}
+
+// Struct validates v, a struct or a pointer to one, recursing into nested structs,
+// pointers, slices and Opt values. It returns Errors listing every failing field,
+// or another error if a tag is malformed.
+func Struct(v any) error {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return fmt.Errorf("validate: expected struct, got %T", v)
+	}
+	var errs Errors
+	if err := walk(&errs, rv, ""); err != nil {
+		return err
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// walk validates the fields of the struct v.
+func walk(errs *Errors, v reflect.Value, path string) error {
+	addr := optreflect.Addressable(v)
+
+	for _, f := range optreflect.Fields(addr.Type(), "json") {
+		fv, err := addr.FieldByIndexErr(f.Index)
+		if err != nil {
+			continue
+		}
+		sub := f.Key
+		if path != "" {
+			sub = path + "." + f.Key
+		}
+		val, set, null := resolve(fv)
+		if tag, ok := f.Tag.Lookup("param"); ok {
+			if err := check(errs, tag, sub, val, set, null); err != nil {
+				return fmt.Errorf("validate: field %q: %w", sub, err)
+			}
+		}
+		if set && !null {
+			if err := descend(errs, val, sub); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// descend validates the structs nested in v.
+func descend(errs *Errors, v reflect.Value, path string) error {
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+	switch v.Kind() {
+	case reflect.Struct:
+		if optreflect.IsObject(v.Type()) {
+			return walk(errs, v, path)
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			if err := descend(errs, v.Index(i), path+"["+strconv.Itoa(i)+"]"); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// resolve returns the value held by the field v and its state. Plain fields are
+// always set, nil pointers are unset.
+func resolve(v reflect.Value) (val reflect.Value, set, null bool) {
+	switch {
+	case optreflect.Is(v.Type()):
+		opt := optreflect.State(v)
+		if !opt.IsSet() || opt.IsNull() {
+			return v, opt.IsSet(), opt.IsNull()
+		}
+		val, _ := optreflect.Get(v)
+		return val, true, false
+	case v.Kind() == reflect.Pointer:
+		if v.IsNil() {
+			return v, false, false
+		}
+		return v.Elem(), true, false
+	}
+	return v, true, false
+}
+
+// check applies the rules of tag to a field.
+func check(errs *Errors, tag, path string, val reflect.Value, set, null bool) error {
+	r, err := paramtag.Parse(tag)
+	if err != nil {
+		return err
+	}
+	fail := func(rule, msg string) {
+		*errs = append(*errs, FieldError{Path: path, Rule: rule, Message: msg})
+	}
+	if r.Required && !set {
+		fail("required", "is required")
+	}
+	if r.NonNull && null {
+		fail("nonnull", "must not be null")
+	}
+	if !set || null || (r.Min == nil && r.Max == nil) {
+		return nil
+	}
+	n, unit, ok := measure(val)
+	if !ok {
+		name := "min"
+		if r.Min == nil {
+			name = "max"
+		}
+		return fmt.Errorf("%s is not supported on %s", name, val.Type())
+	}
+	if r.Min != nil && n < r.Min.Value {
+		fail("min", fmt.Sprintf("must be at least %s%s", r.Min.Text, unit))
+	}
+	if r.Max != nil && n > r.Max.Value {
+		fail("max", fmt.Sprintf("must be at most %s%s", r.Max.Text, unit))
+	}
+	return nil
+}
+
+// measure returns the number compared by min and max: the value of numbers, the
+// length of strings (in characters), slices, arrays and maps.
+func measure(v reflect.Value) (float64, string, bool) {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int()), "", true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return float64(v.Uint()), "", true
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), "", true
+	case reflect.String:
+		return float64(utf8.RuneCountInString(v.String())), " characters", true
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return float64(v.Len()), " elements", true
+	}
+	return 0, "", false
+}
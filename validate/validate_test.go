@@ -0,0 +1,150 @@
+package validate_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/qntx/param"
+	"github.com/qntx/param/validate"
+)
+
+type Address struct {
+	City param.Opt[string] `json:"city" param:"nonnull,min=2"`
+}
+
+type Item struct {
+	SKU param.Opt[string] `json:"sku" param:"required"`
+}
+
+type UserPatch struct {
+	Name    param.Opt[string]         `json:"name" param:"required,nonnull,min=1,max=5"`
+	Age     param.Opt[int]            `json:"age,omitempty" param:"min=1,max=100"`
+	Bio     param.Opt[string]         `json:"bio" param:"nonnull"`
+	Tags    param.Opt[[]string]       `json:"tags" param:"max=2"`
+	Address param.Opt[Address]        `json:"address"`
+	Items   []Item                    `json:"items"`
+	Nick    *string                   `json:"nick" param:"required"`
+	Extra   param.Opt[map[string]int] `json:"-" param:"required"`
+}
+
+func valid() UserPatch {
+	return UserPatch{Name: param.From("Alice"), Nick: param.Ptr("al")}
+}
+
+// TestStruct validates the rules for each field state.
+func TestStruct(t *testing.T) {
+	testCases := []struct {
+		name string
+		edit func(p *UserPatch)
+		want validate.Errors
+	}{
+		{
+			name: "Valid patch",
+			edit: func(p *UserPatch) {},
+		},
+		{
+			name: "Valid patch with values in bounds",
+			edit: func(p *UserPatch) {
+				p.Age, p.Bio, p.Tags = param.From(100), param.From(""), param.From([]string{"a", "b"})
+			},
+		},
+		{
+			name: "Required fields are missing",
+			edit: func(p *UserPatch) { p.Name, p.Nick = nil, nil },
+			want: validate.Errors{
+				{Path: "name", Rule: "required", Message: "is required"},
+				{Path: "nick", Rule: "required", Message: "is required"},
+			},
+		},
+		{
+			name: "Non-null fields are null",
+			edit: func(p *UserPatch) { p.Name, p.Bio = param.Null[string](), param.Null[string]() },
+			want: validate.Errors{
+				{Path: "name", Rule: "nonnull", Message: "must not be null"},
+				{Path: "bio", Rule: "nonnull", Message: "must not be null"},
+			},
+		},
+		{
+			name: "Values out of bounds",
+			edit: func(p *UserPatch) {
+				p.Name, p.Age, p.Tags = param.From(""), param.From(101), param.From([]string{"a", "b", "c"})
+			},
+			want: validate.Errors{
+				{Path: "name", Rule: "min", Message: "must be at least 1 characters"},
+				{Path: "age", Rule: "max", Message: "must be at most 100"},
+				{Path: "tags", Rule: "max", Message: "must be at most 2 elements"},
+			},
+		},
+		{
+			name: "Null values skip bounds",
+			edit: func(p *UserPatch) { p.Age = param.Null[int]() },
+		},
+		{
+			name: "Nested structs and slices are validated",
+			edit: func(p *UserPatch) {
+				p.Address = param.From(Address{City: param.From("X")})
+				p.Items = []Item{{SKU: param.From("a")}, {}}
+			},
+			want: validate.Errors{
+				{Path: "address.city", Rule: "min", Message: "must be at least 2 characters"},
+				{Path: "items[1].sku", Rule: "required", Message: "is required"},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			p := valid()
+			tc.edit(&p)
+			err := validate.Struct(&p)
+			if tc.want == nil {
+				if err != nil {
+					t.Fatalf("Struct() returned an unexpected error: %v", err)
+				}
+				return
+			}
+			var got validate.Errors
+			if !errors.As(err, &got) {
+				t.Fatalf("Struct() error got %v, want validate.Errors", err)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("Struct() got %#v, want %#v", got, tc.want)
+			}
+		})
+	}
+}
+
+// TestErrorsMessage validates the error message listing every field.
+func TestErrorsMessage(t *testing.T) {
+	err := validate.Struct(UserPatch{})
+	want := "validate: name: is required; nick: is required"
+	if err == nil || err.Error() != want {
+		t.Errorf("Struct() error got %v, want %q", err, want)
+	}
+}
+
+// TestMalformedTags validates that invalid tags are reported as errors.
+func TestMalformedTags(t *testing.T) {
+	testCases := map[string]any{
+		"Unknown rule": struct {
+			A param.Opt[int] `param:"positive"`
+		}{},
+		"Invalid bound": struct {
+			A param.Opt[int] `param:"min=x"`
+		}{},
+		"Unsupported type": struct {
+			A param.Opt[bool] `param:"min=1"`
+		}{A: param.From(true)},
+	}
+
+	for name, v := range testCases {
+		t.Run(name, func(t *testing.T) {
+			err := validate.Struct(v)
+			var errs validate.Errors
+			if err == nil || errors.As(err, &errs) {
+				t.Errorf("Struct() error got %v, want a malformed tag error", err)
+			}
+		})
+	}
+}
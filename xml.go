@@ -0,0 +1,119 @@
+package param
+
+import (
+	"encoding/xml"
+
+	"github.com/qntx/param/internal/textconv"
+)
+
+// xsiNamespace is the XML Schema instance namespace defining the nil attribute.
+const xsiNamespace = "http://www.w3.org/2001/XMLSchema-instance"
+
+// Ensure Opt and Val implement the encoding/xml interfaces
+var _ xml.Marshaler = (*Opt[any])(nil)
+var _ xml.Unmarshaler = (*Opt[any])(nil)
+var _ xml.MarshalerAttr = (*Opt[any])(nil)
+var _ xml.UnmarshalerAttr = (*Opt[any])(nil)
+var _ xml.Marshaler = (*Val[any])(nil)
+var _ xml.Unmarshaler = (*Val[any])(nil)
+var _ xml.MarshalerAttr = (*Val[any])(nil)
+var _ xml.UnmarshalerAttr = (*Val[any])(nil)
+
+// MarshalXML implements xml.Marshaler. An unset field emits nothing, a null field
+// emits an empty element with xsi:nil="true", and a value is encoded as usual.
+func (t Opt[T]) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if t.IsNull() {
+		start.Attr = append(start.Attr,
+			xml.Attr{Name: xml.Name{Local: "xmlns:xsi"}, Value: xsiNamespace},
+			xml.Attr{Name: xml.Name{Local: "xsi:nil"}, Value: "true"},
+		)
+		if err := e.EncodeToken(start); err != nil {
+			return err
+		}
+		return e.EncodeToken(start.End())
+	}
+	v, ok := t.Get()
+	if !ok {
+		return nil
+	}
+	return e.EncodeElement(v, start)
+}
+
+// UnmarshalXML implements xml.Unmarshaler. An element with xsi:nil="true" decodes to
+// the null state, any other element is decoded into T.
+func (t *Opt[T]) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	if isNil(start) {
+		t.SetNull()
+		return d.Skip()
+	}
+	var v T
+	if err := d.DecodeElement(&v, &start); err != nil {
+		return err
+	}
+	t.Set(v)
+	return nil
+}
+
+// MarshalXMLAttr implements xml.MarshalerAttr. Attributes cannot carry xsi:nil, so
+// both unset and null fields omit the attribute, while a value is formatted as text.
+func (t Opt[T]) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	v, ok := t.Get()
+	if !ok {
+		return xml.Attr{}, nil
+	}
+	s, err := textconv.Format(v)
+	if err != nil {
+		return xml.Attr{}, err
+	}
+	return xml.Attr{Name: name, Value: s}, nil
+}
+
+// UnmarshalXMLAttr implements xml.UnmarshalerAttr, parsing the attribute value into T.
+func (t *Opt[T]) UnmarshalXMLAttr(attr xml.Attr) error {
+	var v T
+	if err := textconv.Parse(attr.Value, &v); err != nil {
+		return err
+	}
+	t.Set(v)
+	return nil
+}
+
+// MarshalXML implements xml.Marshaler, following the same rules as Opt.MarshalXML.
+func (t Val[T]) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return t.Opt().MarshalXML(e, start)
+}
+
+// UnmarshalXML implements xml.Unmarshaler, following the same rules as Opt.UnmarshalXML.
+func (t *Val[T]) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	o := t.Opt()
+	if err := o.UnmarshalXML(d, start); err != nil {
+		return err
+	}
+	*t = o.Val()
+	return nil
+}
+
+// MarshalXMLAttr implements xml.MarshalerAttr, following the same rules as Opt.MarshalXMLAttr.
+func (t Val[T]) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return t.Opt().MarshalXMLAttr(name)
+}
+
+// UnmarshalXMLAttr implements xml.UnmarshalerAttr, following the same rules as Opt.UnmarshalXMLAttr.
+func (t *Val[T]) UnmarshalXMLAttr(attr xml.Attr) error {
+	o := t.Opt()
+	if err := o.UnmarshalXMLAttr(attr); err != nil {
+		return err
+	}
+	*t = o.Val()
+	return nil
+}
+
+// isNil reports whether start carries xsi:nil="true".
+func isNil(start xml.StartElement) bool {
+	for _, attr := range start.Attr {
+		if attr.Name.Local == "nil" && (attr.Name.Space == xsiNamespace || attr.Name.Space == "xsi") {
+			return attr.Value == "true" || attr.Value == "1"
+		}
+	}
+	return false
+}
@@ -0,0 +1,122 @@
+package param_test
+
+import (
+	"encoding/xml"
+	"testing"
+
+	"github.com/qntx/param"
+)
+
+type xmlPayload struct {
+	XMLName xml.Name          `xml:"user"`
+	ID      param.Opt[int]    `xml:"id,attr"`
+	Name    param.Opt[string] `xml:"name"`
+	Age     param.Val[int]    `xml:"age"`
+}
+
+// TestXMLMarshaling validates the XML serialization logic.
+func TestXMLMarshaling(t *testing.T) {
+	testCases := []struct {
+		name  string
+		input xmlPayload
+		want  string
+	}{
+		{
+			name:  "All fields valid",
+			input: xmlPayload{ID: param.From(7), Name: param.From("Alice"), Age: param.ValOf(30)},
+			want:  `<user id="7"><name>Alice</name><age>30</age></user>`,
+		},
+		{
+			name:  "Unset fields are omitted",
+			input: xmlPayload{},
+			want:  `<user></user>`,
+		},
+		{
+			name:  "Null fields are xsi:nil",
+			input: xmlPayload{ID: param.Null[int](), Name: param.Null[string](), Age: param.NullVal[int]()},
+			want: `<user>` +
+				`<name xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xsi:nil="true"></name>` +
+				`<age xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xsi:nil="true"></age>` +
+				`</user>`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := xml.Marshal(tc.input)
+			if err != nil {
+				t.Fatalf("xml.Marshal() returned an unexpected error: %v", err)
+			}
+			if string(got) != tc.want {
+				t.Errorf("xml.Marshal() got %s, want %s", got, tc.want)
+			}
+		})
+	}
+}
+
+// TestXMLUnmarshaling validates the XML deserialization logic.
+func TestXMLUnmarshaling(t *testing.T) {
+	t.Run("All fields present and valid", func(t *testing.T) {
+		var p xmlPayload
+		if err := xml.Unmarshal([]byte(`<user id="7"><name>Alice</name><age>30</age></user>`), &p); err != nil {
+			t.Fatalf("xml.Unmarshal() failed: %v", err)
+		}
+		if p.ID.MustGet() != 7 || p.Name.MustGet() != "Alice" || p.Age.MustGet() != 30 {
+			t.Error("Did not unmarshal valid values correctly")
+		}
+	})
+
+	t.Run("Missing fields are unset", func(t *testing.T) {
+		var p xmlPayload
+		if err := xml.Unmarshal([]byte(`<user></user>`), &p); err != nil {
+			t.Fatalf("xml.Unmarshal() failed: %v", err)
+		}
+		if p.ID.IsSet() || p.Name.IsSet() || p.Age.IsSet() {
+			t.Error("Missing fields should be unset")
+		}
+	})
+
+	t.Run("Empty element is a value", func(t *testing.T) {
+		var p xmlPayload
+		if err := xml.Unmarshal([]byte(`<user><name></name></user>`), &p); err != nil {
+			t.Fatalf("xml.Unmarshal() failed: %v", err)
+		}
+		if v, ok := p.Name.Get(); !ok || v != "" {
+			t.Errorf("Get() got (%q, %v), want (\"\", true)", v, ok)
+		}
+	})
+
+	t.Run("xsi:nil elements are null", func(t *testing.T) {
+		input := `<user xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance">` +
+			`<name xsi:nil="true"/><age xsi:nil="1"></age></user>`
+		var p xmlPayload
+		if err := xml.Unmarshal([]byte(input), &p); err != nil {
+			t.Fatalf("xml.Unmarshal() failed: %v", err)
+		}
+		if !p.Name.IsNull() || !p.Age.IsNull() {
+			t.Error("Fields should be Null after unmarshaling xsi:nil")
+		}
+	})
+
+	t.Run("Round trip", func(t *testing.T) {
+		in := xmlPayload{ID: param.From(1), Name: param.Null[string](), Age: param.ValOf(0)}
+		data, err := xml.Marshal(in)
+		if err != nil {
+			t.Fatalf("xml.Marshal() returned an unexpected error: %v", err)
+		}
+		var out xmlPayload
+		if err := xml.Unmarshal(data, &out); err != nil {
+			t.Fatalf("xml.Unmarshal() failed: %v", err)
+		}
+		if out.ID.MustGet() != 1 || !out.Name.IsNull() || out.Age.MustGet() != 0 {
+			t.Errorf("Round trip got %+v, want %+v", out, in)
+		}
+	})
+
+	t.Run("Type mismatch error", func(t *testing.T) {
+		var p xmlPayload
+		if err := xml.Unmarshal([]byte(`<user id="x"></user>`), &p); err == nil {
+			t.Fatal("Expected a type mismatch error but got nil")
+		}
+	})
+}
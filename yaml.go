@@ -0,0 +1,105 @@
+package param
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// The YAML methods follow the interfaces shared by gopkg.in/yaml.v2, gopkg.in/yaml.v3
+// and github.com/goccy/go-yaml, so no YAML library is imported. Those decoders skip
+// custom unmarshalers for null nodes (`key: null`, `key: ~`, `key:`): they reset an
+// Opt to unset and leave a Val untouched, so the null state is lost. Use DecodeYAML,
+// or a decoder that converts YAML to JSON first such as sigs.k8s.io/yaml, to keep
+// it.
+
+// DecodeYAML decodes the YAML document data into v, keeping explicit nulls. The
+// document is decoded into generic values with unmarshal, e.g. yaml.Unmarshal from
+// gopkg.in/yaml.v3, and then into v through JSON, so fields are matched by their
+// `json` tags and types implementing json.Unmarshaler decode as usual.
+func DecodeYAML(data []byte, v any, unmarshal func([]byte, any) error) error {
+	var doc any
+	if err := unmarshal(data, &doc); err != nil {
+		return err
+	}
+	js, err := json.Marshal(jsonValue(doc))
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(js, v)
+}
+
+// jsonValue converts the maps with non-string keys produced by YAML decoders into
+// maps that encoding/json can marshal.
+func jsonValue(v any) any {
+	switch v := v.(type) {
+	case map[any]any:
+		m := make(map[string]any, len(v))
+		for k, e := range v {
+			m[fmt.Sprint(k)] = jsonValue(e)
+		}
+		return m
+	case map[string]any:
+		for k, e := range v {
+			v[k] = jsonValue(e)
+		}
+	case []any:
+		for i, e := range v {
+			v[i] = jsonValue(e)
+		}
+	}
+	return v
+}
+
+// MarshalYAML implements the YAML Marshaler interface. A null field is encoded as
+// `null`; an unset field is omitted under `omitempty` since Opt implements IsZero.
+func (t Opt[T]) MarshalYAML() (any, error) {
+	if t.IsNull() {
+		return nil, nil
+	}
+	return t[true], nil
+}
+
+// UnmarshalYAML implements the YAML Unmarshaler interface. A null node decodes to
+// the null state, any other node is decoded into T.
+func (t *Opt[T]) UnmarshalYAML(unmarshal func(any) error) error {
+	var raw any
+	if err := unmarshal(&raw); err != nil {
+		return err
+	}
+	if raw == nil {
+		t.SetNull()
+		return nil
+	}
+	var v T
+	if err := unmarshal(&v); err != nil {
+		return err
+	}
+	t.Set(v)
+	return nil
+}
+
+// MarshalYAML implements the YAML Marshaler interface, following the same rules as Opt.MarshalYAML.
+func (t Val[T]) MarshalYAML() (any, error) {
+	if t.state == stateNull {
+		return nil, nil
+	}
+	return t.v, nil
+}
+
+// UnmarshalYAML implements the YAML Unmarshaler interface, following the same rules as Opt.UnmarshalYAML.
+func (t *Val[T]) UnmarshalYAML(unmarshal func(any) error) error {
+	var raw any
+	if err := unmarshal(&raw); err != nil {
+		return err
+	}
+	if raw == nil {
+		t.SetNull()
+		return nil
+	}
+	var v T
+	if err := unmarshal(&v); err != nil {
+		return err
+	}
+	t.Set(v)
+	return nil
+}
@@ -0,0 +1,148 @@
+package param_test
+
+import (
+	"reflect"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/qntx/param"
+)
+
+// TestYAMLMarshaling validates the YAML serialization logic.
+func TestYAMLMarshaling(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    interface{ MarshalYAML() (any, error) }
+		want     any
+		wantZero bool
+	}{
+		{name: "Valid", input: param.From("hello"), want: "hello"},
+		{name: "Valid zero value", input: param.From(0), want: 0},
+		{name: "Null", input: param.Null[string](), want: nil},
+		{name: "Unset (omitted by omitempty)", input: param.Zero[int](), want: 0, wantZero: true},
+		{name: "Val valid", input: param.ValOf(true), want: true},
+		{name: "Val null", input: param.NullVal[bool](), want: nil},
+		{name: "Val unset (omitted by omitempty)", input: param.Val[bool]{}, want: false, wantZero: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := tc.input.MarshalYAML()
+			if err != nil {
+				t.Fatalf("MarshalYAML() returned an unexpected error: %v", err)
+			}
+			if got != tc.want {
+				t.Errorf("MarshalYAML() got %#v, want %#v", got, tc.want)
+			}
+			if z := tc.input.(interface{ IsZero() bool }).IsZero(); z != tc.wantZero {
+				t.Errorf("IsZero() got %v, want %v", z, tc.wantZero)
+			}
+		})
+	}
+}
+
+type yamlPayload struct {
+	Name  param.Opt[string]   `json:"name,omitempty" yaml:"name,omitempty"`
+	Age   param.Opt[int]      `json:"age,omitempty" yaml:"age,omitempty"`
+	Bio   param.Opt[string]   `json:"bio,omitempty" yaml:"bio,omitempty"`
+	Tags  param.Opt[[]string] `json:"tags,omitempty" yaml:"tags,omitempty"`
+	Admin param.Val[bool]     `json:"admin,omitzero" yaml:"admin,omitempty"`
+	Score param.Val[float64]  `json:"score,omitzero" yaml:"score,omitempty"`
+}
+
+// TestYAMLRoundTrip validates marshaling and unmarshaling whole structs with
+// gopkg.in/yaml.v3.
+func TestYAMLRoundTrip(t *testing.T) {
+	in := yamlPayload{
+		Name:  param.From("Alice"),
+		Age:   param.Null[int](),
+		Tags:  param.From([]string{"a", "b"}),
+		Admin: param.ValOf(false),
+		Score: param.NullVal[float64](),
+	}
+
+	data, err := yaml.Marshal(in)
+	if err != nil {
+		t.Fatalf("yaml.Marshal() returned an unexpected error: %v", err)
+	}
+	want := "name: Alice\nage: null\ntags:\n    - a\n    - b\nadmin: false\nscore: null\n"
+	if string(data) != want {
+		t.Errorf("yaml.Marshal() got:\n%s\nwant:\n%s", data, want)
+	}
+
+	t.Run("DecodeYAML keeps null", func(t *testing.T) {
+		var got yamlPayload
+		if err := param.DecodeYAML(data, &got, yaml.Unmarshal); err != nil {
+			t.Fatalf("DecodeYAML() returned an unexpected error: %v", err)
+		}
+		if !reflect.DeepEqual(got, in) {
+			t.Errorf("DecodeYAML() got %+v, want %+v", got, in)
+		}
+	})
+
+	t.Run("yaml.Unmarshal loses null", func(t *testing.T) {
+		// Documents the limitation: yaml.v3 does not call UnmarshalYAML for null
+		// nodes, so a null Opt comes back unset and a null Val is left untouched.
+		got := yamlPayload{Score: param.ValOf(1.5)}
+		if err := yaml.Unmarshal(data, &got); err != nil {
+			t.Fatalf("yaml.Unmarshal() returned an unexpected error: %v", err)
+		}
+		if !param.Equal(got.Name, in.Name) || !reflect.DeepEqual(got.Tags, in.Tags) || got.Admin != in.Admin {
+			t.Errorf("yaml.Unmarshal() got %+v, want values matching %+v", got, in)
+		}
+		if got.Age.IsSet() || got.Score != param.ValOf(1.5) {
+			t.Errorf("yaml.Unmarshal() got age=%v score=%v, want the documented unset and untouched states", got.Age, got.Score)
+		}
+	})
+}
+
+// TestDecodeYAML validates the YAML null forms and errors.
+func TestDecodeYAML(t *testing.T) {
+	testCases := []struct {
+		name   string
+		input  string
+		isNull bool
+		isSet  bool
+		want   int
+	}{
+		{name: "Value", input: "age: 123", isSet: true, want: 123},
+		{name: "Zero value", input: "age: 0", isSet: true},
+		{name: "Explicit null", input: "age: null", isSet: true, isNull: true},
+		{name: "Tilde null", input: "age: ~", isSet: true, isNull: true},
+		{name: "Empty value", input: "age:", isSet: true, isNull: true},
+		{name: "Missing", input: "name: Bob"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var p yamlPayload
+			if err := param.DecodeYAML([]byte(tc.input), &p, yaml.Unmarshal); err != nil {
+				t.Fatalf("DecodeYAML() returned an unexpected error: %v", err)
+			}
+			if p.Age.IsSet() != tc.isSet || p.Age.IsNull() != tc.isNull {
+				t.Errorf("state got (set=%v, null=%v), want (set=%v, null=%v)", p.Age.IsSet(), p.Age.IsNull(), tc.isSet, tc.isNull)
+			}
+			if got, _ := p.Age.Get(); got != tc.want {
+				t.Errorf("Get() got %d, want %d", got, tc.want)
+			}
+		})
+	}
+
+	t.Run("Non-string keys", func(t *testing.T) {
+		var got map[string]param.Opt[string]
+		if err := param.DecodeYAML([]byte("1: one\ntrue: ~"), &got, yaml.Unmarshal); err != nil {
+			t.Fatalf("DecodeYAML() returned an unexpected error: %v", err)
+		}
+		if !param.Equal(got["1"], param.From("one")) || !got["true"].IsNull() {
+			t.Errorf("DecodeYAML() got %v", got)
+		}
+	})
+
+	t.Run("Type mismatch error", func(t *testing.T) {
+		var p yamlPayload
+		if err := param.DecodeYAML([]byte("age: abc"), &p, yaml.Unmarshal); err == nil {
+			t.Fatal("Expected a type mismatch error but got nil")
+		}
+	})
+}